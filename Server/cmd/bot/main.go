@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"server/pkg/bot"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	addr         = flag.String("addr", "ws://localhost:8080/ws", "server address, ws://host:port/ws or tcp://host:port")
+	count        = flag.Int("n", 10, "number of bots to launch")
+	prefix       = flag.String("prefix", "loadbot", "bot usernames are <prefix>-<n>")
+	password     = flag.String("password", "", "password the bots register and log in with (required)")
+	ramp         = flag.Duration("ramp", 50*time.Millisecond, "delay between launching bots")
+	duration     = flag.Duration("duration", 0, "stop after this long (0 runs until interrupted)")
+	maxFrameSize = flag.Int("max-frame-size", 1<<20, "largest packet accepted from the server")
+)
+
+// 启动 N 个机器人连到服务器，用来压测或者填充人少的服务器
+func main() {
+	flag.Parse()
+
+	// 机器人注册的是真正的账号，不能用写在代码里的密码
+	if *password == "" {
+		log.Fatal("-password is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+
+	var wg sync.WaitGroup
+
+launch:
+	for i := 1; i <= *count; i++ {
+		username := fmt.Sprintf("%s-%d", *prefix, i)
+
+		conn, err := bot.Dial(*addr, *maxFrameSize)
+		if err != nil {
+			log.Printf("Bot %s failed to connect: %v", username, err)
+		} else {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				if err := bot.New(conn, username, *password).Run(ctx); err != nil {
+					log.Printf("Bot %s stopped: %v", username, err)
+				}
+			}()
+		}
+
+		select {
+		case <-time.After(*ramp):
+		case <-ctx.Done():
+			break launch
+		}
+	}
+
+	log.Printf("Launched bots against %s", *addr)
+	wg.Wait()
+	log.Println("All bots stopped")
+}
@@ -1,23 +1,54 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
 	"server/internal/server"
 	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/pkg/bot"
+	"syscall"
 )
 
 var (
-	port = flag.Int("port", 8080, "port to listen on")
+	configPath      = flag.String("config", "", "path to a JSON config file (MMO_* environment variables override it)")
+	port            = flag.Int("port", 0, "port to listen on (overrides the config)")
+	shutdownTimeout = flag.Duration("shutdown-timeout", 0, "how long to wait for clients to disconnect on shutdown (overrides the config)")
 )
 
 func main() {
 	flag.Parse()
 
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Loading config: %v", err)
+	}
+
+	// 命令行参数优先于配置文件和环境变量
+	if *port != 0 {
+		cfg.Port = *port
+	}
+	if *shutdownTimeout != 0 {
+		cfg.ShutdownTimeout = config.Duration(*shutdownTimeout)
+	}
+
+	// 子命令：server migrate up/down/status
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(cfg, flag.Args()[1:]); err != nil {
+			log.Fatalf("Migrate: %v", err)
+		}
+		return
+	}
+
 	// Define the game hub
-	hub := server.NewHub()
+	hub := server.NewHub(cfg)
 
 	// Define handler for WebSocket connections
 	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
@@ -26,13 +57,95 @@ func main() {
 
 	go hub.Run()
 
-	addr := fmt.Sprintf(":%d", *port)
+	// Hub 里的机器人，服务器关闭时跟着断开
+	for i := 1; i <= cfg.Bots.Count; i++ {
+		client := clients.NewPipeClient(hub)
+		go hub.Serve(client)
 
-	log.Printf("Starting server on %s", addr)
+		b := bot.New(client.Conn(), fmt.Sprintf("%s-%d", cfg.Bots.NamePrefix, i), cfg.Bots.Password)
+		go func() {
+			if err := b.Run(context.Background()); err != nil {
+				log.Printf("Bot %s stopped: %v", b.Username, err)
+			}
+		}()
+	}
 
-	err := http.ListenAndServe(addr, nil)
+	addr := fmt.Sprintf(":%d", cfg.Port)
+	httpServer := &http.Server{Addr: addr}
 
-	if err != nil {
-		log.Fatalf("ListenAndServer %v", err)
+	// SIGINT/SIGTERM 时关闭服务器
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		log.Printf("Starting server on %s", addr)
+
+		err := httpServer.ListenAndServe()
+
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("ListenAndServer %v", err)
+		}
+	}()
+
+	// 可选的 TCP 和 UDP 传输
+	var tcpListener net.Listener
+	if cfg.Network.TCPPort != 0 {
+		tcpListener, err = net.Listen("tcp", fmt.Sprintf(":%d", cfg.Network.TCPPort))
+		if err != nil {
+			log.Fatalf("Listening for TCP clients: %v", err)
+		}
+
+		go func() {
+			log.Printf("Accepting TCP clients on %s", tcpListener.Addr())
+			if err := clients.ServeTCP(hub, tcpListener); err != nil {
+				log.Fatalf("ServeTCP %v", err)
+			}
+		}()
 	}
+
+	var udpServer *clients.UDPServer
+	if cfg.Network.UDPPort != 0 {
+		udpConn, err := net.ListenUDP("udp", &net.UDPAddr{Port: cfg.Network.UDPPort})
+		if err != nil {
+			log.Fatalf("Listening for UDP clients: %v", err)
+		}
+
+		udpServer = clients.NewUDPServer(hub, udpConn)
+		go func() {
+			log.Printf("Accepting UDP clients on %s", udpConn.LocalAddr())
+			if err := udpServer.Serve(); err != nil {
+				log.Fatalf("ServeUDP %v", err)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	stop()
+
+	log.Printf("Shutting down (deadline %s)", cfg.ShutdownTimeout.Duration())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout.Duration())
+	defer cancel()
+
+	// 先停止接受新的连接
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down http server: %v", err)
+	}
+	if tcpListener != nil {
+		tcpListener.Close()
+	}
+	if udpServer != nil {
+		udpServer.StopAccepting()
+	}
+
+	if err := hub.Shutdown(shutdownCtx, "Server is shutting down"); err != nil {
+		log.Printf("Error shutting down hub: %v", err)
+	}
+
+	// UDP 客户端发完最后的包之后才能关闭 socket
+	if udpServer != nil {
+		udpServer.Close()
+	}
+
+	log.Println("Server stopped")
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"server/internal/server/config"
+	"server/internal/server/db"
+	"server/internal/server/db/migrations"
+)
+
+const migrateUsage = `usage: server [flags] migrate <command>
+
+commands:
+  up          apply every pending migration (the server also does this on startup)
+  down [n]    roll back the last n applied migrations (default 1)
+  status      list the migrations and whether they are applied`
+
+// 数据库迁移的子命令：migrate up / down [n] / status
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+
+	store, err := db.Open(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	migrator, err := migrations.New(store.DB, store.Driver)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("Applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("Database is up to date")
+		}
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations to roll back: %q", args[1])
+			}
+		}
+
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, migration := range rolledBack {
+			fmt.Printf("Rolled back %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(rolledBack) == 0 {
+			fmt.Println("No migrations to roll back")
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := status.AppliedAt
+			if !status.Applied() {
+				appliedAt = "pending"
+			}
+			fmt.Fprintf(writer, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return writer.Flush()
+	}
+
+	return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+}
@@ -0,0 +1,349 @@
+package clients
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"server/internal/server"
+	"server/internal/server/config"
+	"server/internal/server/states"
+	"server/pkg/packets"
+)
+
+// 和传输方式无关的客户端逻辑，WebSocket、TCP 和 UDP 客户端都嵌入它，
+// 各自只实现 ReadPump 和 WritePump
+type baseClient struct {
+	// 重连时会换成旧玩家的 ID，Hub 和读协程都会读它
+	id       atomic.Uint64
+	hub      *server.Hub
+	sendChan chan *packets.Packet
+	// 只在 run 的协程里访问
+	state  server.ClientStateHandler
+	logger *log.Logger
+	dbTx   *server.DbTx
+	// 握手时在状态机的协程里设置，Hub 发送视野和快照时读
+	protocol atomic.Pointer[protocol]
+
+	// 外层的客户端，交给状态机和 Hub 的一定是它
+	self server.ClientInterfacer
+
+	// 由传输方式设置，进程内的客户端为空
+	remoteIP string
+
+	// Hub 分配 ID 之后关闭，ReadPump 收到的包要等它
+	initialized chan struct{}
+
+	// 交给状态机的事件：收到的包、Hub 和其他客户端发来的消息、进入 Connected 和关闭。
+	// 状态机只在 run 的协程里按顺序执行它们，队列没有上限，Hub 不会因为某个客户端忙而被阻塞
+	events     []func()
+	eventsMux  sync.Mutex
+	eventsWake chan struct{}
+	runOnce    sync.Once
+	// 关闭之后丢掉还没执行的事件，也不再接收新的
+	stopped bool
+
+	// 关闭之后不能再往 sendChan 里发送
+	closeOnce   sync.Once
+	sendMux     sync.RWMutex
+	closed      bool
+	closeReason string
+}
+
+// 握手的结果，设置之后不再修改
+type protocol struct {
+	version  uint32
+	features map[string]bool
+}
+
+// 去掉端口，只留 IP
+func remoteIP(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+func newBaseClient(hub *server.Hub, self server.ClientInterfacer) baseClient {
+	return baseClient{
+		hub:      hub,
+		sendChan: make(chan *packets.Packet, hub.Config.Network.SendQueueSize),
+		logger:   log.New(log.Writer(), "Client unknown", log.LstdFlags),
+		dbTx:     hub.NewDbTx(),
+		self:     self,
+
+		initialized: make(chan struct{}),
+		eventsWake:  make(chan struct{}, 1),
+	}
+}
+
+func (c *baseClient) Id() uint64 {
+	return c.id.Load()
+}
+
+// 把事件交给状态机的协程，第一次调用时启动它
+func (c *baseClient) do(event func()) {
+	c.eventsMux.Lock()
+	if c.stopped {
+		c.eventsMux.Unlock()
+		return
+	}
+	c.events = append(c.events, event)
+	c.eventsMux.Unlock()
+
+	c.runOnce.Do(func() {
+		go c.run()
+	})
+
+	select {
+	case c.eventsWake <- struct{}{}:
+	default:
+	}
+}
+
+// 状态机的协程，SetState 和状态的处理函数都在这里执行
+func (c *baseClient) run() {
+	for range c.eventsWake {
+		for {
+			c.eventsMux.Lock()
+			if c.stopped {
+				c.eventsMux.Unlock()
+				return
+			}
+			if len(c.events) == 0 {
+				c.eventsMux.Unlock()
+				break
+			}
+			event := c.events[0]
+			c.events[0] = nil
+			c.events = c.events[1:]
+			c.eventsMux.Unlock()
+
+			event()
+		}
+	}
+}
+
+// 只能在状态机的协程里调用，也就是状态的处理函数里
+func (c *baseClient) SetState(state server.ClientStateHandler) {
+	prevStateName := "None"
+
+	if c.state != nil {
+		prevStateName = c.state.Name()
+		c.state.OnExit()
+	}
+
+	newStateName := "None"
+
+	if state != nil {
+		newStateName = state.Name()
+	}
+
+	c.logger.Printf("Switching from state %s to %s", prevStateName, newStateName)
+
+	c.state = state
+
+	if c.state != nil {
+		c.state.SetClient(c.self)
+		c.state.OnEnter()
+	}
+}
+
+func (c *baseClient) ProcessMessage(senderId uint64, message packets.Msg) {
+	// 别的客户端（比如同一个账号的新登录）要求断开，和当前状态无关。客户端自己发来的不算
+	if kick, ok := message.(*packets.Packet_Kick); ok && senderId != c.Id() {
+		c.SocketSend(kick)
+		c.Close(kick.Kick.Reason)
+		return
+	}
+
+	c.do(func() {
+		c.handleMessage(senderId, message)
+	})
+}
+
+func (c *baseClient) handleMessage(senderId uint64, message packets.Msg) {
+	if c.state != nil {
+		c.state.HandlerMessage(senderId, message)
+	}
+}
+
+func (c *baseClient) SocketSend(message packets.Msg) {
+	c.SocketSendAs(message, c.Id())
+}
+
+// PassToPeer is a method for passing a message to another peer.
+// peerId is the id of the peer that the message should be sent to.
+// The method is used by the server to send messages to other peers.
+func (c *baseClient) PassToPeer(message packets.Msg, peerId uint64) {
+	if peer, exists := c.hub.Clients.Get(peerId); exists {
+		peer.ProcessMessage(c.Id(), message)
+	}
+}
+
+// 关闭也在状态机的协程里执行，排在已经收到的事件后面
+func (c *baseClient) Close(reson string) {
+	c.closeOnce.Do(func() {
+		c.logger.Printf("Closing Connection because %s", reson)
+		c.do(func() {
+			c.close(reson)
+		})
+	})
+}
+
+func (c *baseClient) close(reson string) {
+	id := c.Id()
+
+	// 登录过的玩家保留在世界里，等待重连
+	c.hub.Sessions.BeginClose(id)
+	c.SetState(nil)
+
+	// 不等重连的话就让出房间的位置
+	if !c.hub.Sessions.IsDisconnected(id) {
+		c.hub.Rooms.Leave(id)
+	}
+
+	c.hub.UnregisterChan <- c.self
+
+	// WritePump 写完剩下的包之后关闭连接
+	c.sendMux.Lock()
+	c.closed = true
+	c.closeReason = reson
+	close(c.sendChan)
+	c.sendMux.Unlock()
+
+	c.eventsMux.Lock()
+	c.stopped = true
+	c.events = nil
+	c.eventsMux.Unlock()
+}
+
+func (c *baseClient) Initialize(id uint64) {
+	c.id.Store(id)
+	c.logger.SetPrefix(fmt.Sprintf("ClientID : %d ,", id))
+	c.do(func() {
+		c.SetState(&states.Connected{})
+		c.logger.Printf("Sent ID to Client")
+	})
+	close(c.initialized)
+}
+
+func (c *baseClient) SocketSendAs(message packets.Msg, senderId uint64) {
+	c.sendMux.RLock()
+	defer c.sendMux.RUnlock()
+
+	if c.closed {
+		return
+	}
+
+	if c.ProtocolVersion() == packets.LegacyProtocolVersion {
+		if message = packets.LegacyMessage(message); message == nil {
+			return
+		}
+	}
+
+	select {
+	case c.sendChan <- &packets.Packet{SenderId: senderId, Msg: message}:
+	default:
+		c.logger.Printf("send channel full,droping message %T", message)
+	}
+}
+
+func (c *baseClient) Broadcast(message packets.Msg) {
+	c.hub.BroadcastChan <- &packets.Packet{SenderId: c.Id(), Msg: message}
+}
+
+func (c *baseClient) QueueInput(message packets.Msg) {
+	c.hub.InputChan <- &server.PlayerInput{ClientId: c.Id(), Msg: message}
+}
+
+func (c *baseClient) SetProtocol(version uint32, features []string) {
+	p := &protocol{
+		version:  version,
+		features: make(map[string]bool, len(features)),
+	}
+	for _, feature := range features {
+		p.features[feature] = true
+	}
+	c.protocol.Store(p)
+}
+
+func (c *baseClient) ProtocolVersion() uint32 {
+	if p := c.protocol.Load(); p != nil {
+		return p.version
+	}
+	return 0
+}
+
+func (c *baseClient) HasFeature(feature string) bool {
+	p := c.protocol.Load()
+	return p != nil && p.features[feature]
+}
+
+func (c *baseClient) Config() *config.Config {
+	return c.hub.Config
+}
+
+func (c *baseClient) Sessions() *server.SessionStore {
+	return c.hub.Sessions
+}
+
+func (c *baseClient) RateLimiter() *server.RateLimiter {
+	return c.hub.RateLimiter
+}
+
+func (c *baseClient) RemoteIP() string {
+	return c.remoteIP
+}
+
+func (c *baseClient) Reattach(id uint64) {
+	c.hub.Clients.Remove(c.Id())
+	c.hub.Clients.Add(c.self, id)
+	c.id.Store(id)
+	c.logger.SetPrefix(fmt.Sprintf("ClientID : %d ,", id))
+}
+
+func (c *baseClient) DbTx() *server.DbTx {
+	return c.dbTx
+}
+
+// 返回所在房间的共享游戏的集合
+func (c *baseClient) SharedGameObjects() *server.SharedGameObjects {
+	room, exists := c.hub.Rooms.RoomOf(c.Id())
+	if !exists {
+		return nil
+	}
+	return room.SharedGameObjects
+}
+
+func (c *baseClient) Rooms() *server.RoomManager {
+	return c.hub.Rooms
+}
+
+func (c *baseClient) Matchmaker() *server.Matchmaker {
+	return c.hub.Matchmaker
+}
+
+func (c *baseClient) Lobby() *server.Lobby {
+	return c.hub.Lobby
+}
+
+func (c *baseClient) Spectators() *server.Spectators {
+	return c.hub.Spectators
+}
+
+func (c *baseClient) PeakRadii() *server.PeakRadii {
+	return c.hub.PeakRadii
+}
+
+// 从连接上读到的包一定是自己发的，不能冒充别人或服务器。处理时才取 ID，重连之后就是旧玩家的 ID
+func (c *baseClient) receive(packet *packets.Packet) {
+	<-c.initialized
+
+	c.do(func() {
+		c.handleMessage(c.Id(), packet.Msg)
+	})
+}
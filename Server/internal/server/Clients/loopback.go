@@ -0,0 +1,144 @@
+package clients
+
+import (
+	"sync"
+	"time"
+
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+// An in-process client without a socket, for exercising the state machine in tests. It
+// registers with a hub like any other transport; packets injected with Inject are handled
+// as if they came off the wire, and everything sent to the client or broadcast by it is
+// recorded in order.
+type LoopbackClient struct {
+	baseClient
+
+	// 模拟从连接上读到的包，关闭之后 ReadPump 退出
+	inbox     chan *packets.Packet
+	closeOnce sync.Once
+
+	recordMux  sync.Mutex
+	sent       []*packets.Packet
+	broadcasts []packets.Msg
+	// 每记录一个包就关闭并换成新的，用来唤醒等待的测试
+	recorded chan struct{}
+}
+
+func NewLoopbackClient(hub *server.Hub) *LoopbackClient {
+	c := &LoopbackClient{
+		inbox:    make(chan *packets.Packet, hub.Config.Network.SendQueueSize),
+		recorded: make(chan struct{}),
+	}
+	c.baseClient = newBaseClient(hub, c)
+	return c
+}
+
+// 像从连接上读到的一样处理这个包
+func (c *LoopbackClient) Inject(message packets.Msg) {
+	c.inbox <- &packets.Packet{Msg: message}
+}
+
+// 模拟对方断开连接
+func (c *LoopbackClient) Disconnect() {
+	c.closeOnce.Do(func() {
+		close(c.inbox)
+	})
+}
+
+// 到目前为止发给客户端的所有包
+func (c *LoopbackClient) Sent() []*packets.Packet {
+	c.recordMux.Lock()
+	defer c.recordMux.Unlock()
+
+	return append([]*packets.Packet(nil), c.sent...)
+}
+
+// 到目前为止客户端广播的所有消息
+func (c *LoopbackClient) Broadcasts() []packets.Msg {
+	c.recordMux.Lock()
+	defer c.recordMux.Unlock()
+
+	return append([]packets.Msg(nil), c.broadcasts...)
+}
+
+// WaitFor waits until a packet matching match has been sent to the client and returns the
+// first one, or returns false after timeout.
+func (c *LoopbackClient) WaitFor(match func(*packets.Packet) bool, timeout time.Duration) (*packets.Packet, bool) {
+	matches, ok := c.WaitForCount(match, 1, timeout)
+	if !ok {
+		return nil, false
+	}
+	return matches[0], true
+}
+
+// WaitForCount waits until at least n packets matching match have been sent to the client
+// and returns all of them, or returns false after timeout.
+func (c *LoopbackClient) WaitForCount(match func(*packets.Packet) bool, n int, timeout time.Duration) ([]*packets.Packet, bool) {
+	deadline := time.After(timeout)
+
+	for {
+		c.recordMux.Lock()
+		var matches []*packets.Packet
+		for _, packet := range c.sent {
+			if match(packet) {
+				matches = append(matches, packet)
+			}
+		}
+		recorded := c.recorded
+		c.recordMux.Unlock()
+
+		if len(matches) >= n {
+			return matches, true
+		}
+
+		select {
+		case <-recorded:
+		case <-deadline:
+			return matches, false
+		}
+	}
+}
+
+func (c *LoopbackClient) Broadcast(message packets.Msg) {
+	c.recordMux.Lock()
+	c.broadcasts = append(c.broadcasts, message)
+	c.notifyLocked()
+	c.recordMux.Unlock()
+
+	c.baseClient.Broadcast(message)
+}
+
+func (c *LoopbackClient) ReadPump() {
+	defer func() {
+		c.logger.Printf("Close read pump")
+		c.Close("read pump closed")
+	}()
+
+	for packet := range c.inbox {
+		c.receive(packet)
+	}
+}
+
+func (c *LoopbackClient) WritePump() {
+	defer func() {
+		c.logger.Println("Closing Write Pump")
+		c.Close("write pump closed")
+		c.Disconnect()
+	}()
+
+	// sendChan 关闭之后会先把剩下的包记录完
+	for packet := range c.sendChan {
+		c.recordMux.Lock()
+		c.sent = append(c.sent, packet)
+		c.notifyLocked()
+		c.recordMux.Unlock()
+	}
+}
+
+// 调用方需要持有 recordMux
+func (c *LoopbackClient) notifyLocked() {
+	close(c.recorded)
+	c.recorded = make(chan struct{})
+}
@@ -0,0 +1,106 @@
+package clients
+
+import (
+	"sync"
+
+	"server/internal/server"
+	"server/pkg/bot"
+	"server/pkg/packets"
+)
+
+// A client without a socket whose other end is a bot.Conn inside the same process, used
+// to run bots in the hub. Unlike LoopbackClient it records nothing, so it can stay
+// connected for as long as the server runs.
+type PipeClient struct {
+	baseClient
+
+	// 机器人发给服务器的包
+	inbox chan *packets.Packet
+	// 服务器发给机器人的包，WritePump 退出时关闭
+	outbox chan *packets.Packet
+
+	// 机器人那一端关闭连接
+	disconnected   chan struct{}
+	disconnectOnce sync.Once
+	// ReadPump 已经退出，不会再有人读 inbox
+	readerDone chan struct{}
+}
+
+func NewPipeClient(hub *server.Hub) *PipeClient {
+	c := &PipeClient{
+		inbox:        make(chan *packets.Packet, hub.Config.Network.SendQueueSize),
+		outbox:       make(chan *packets.Packet, hub.Config.Network.SendQueueSize),
+		disconnected: make(chan struct{}),
+		readerDone:   make(chan struct{}),
+	}
+	c.baseClient = newBaseClient(hub, c)
+	return c
+}
+
+// 机器人那一端的连接
+func (c *PipeClient) Conn() bot.Conn {
+	return &pipeConn{client: c}
+}
+
+func (c *PipeClient) ReadPump() {
+	defer func() {
+		c.logger.Printf("Close read pump")
+		close(c.readerDone)
+		c.Close("read pump closed")
+	}()
+
+	for {
+		select {
+		case packet := <-c.inbox:
+			c.receive(packet)
+		case <-c.disconnected:
+			return
+		}
+	}
+}
+
+func (c *PipeClient) WritePump() {
+	defer func() {
+		c.logger.Println("Closing Write Pump")
+		c.Close("write pump closed")
+		close(c.outbox)
+	}()
+
+	// 机器人断开之后继续取出剩下的包，直到 sendChan 关闭
+	for packet := range c.sendChan {
+		select {
+		case c.outbox <- packet:
+		case <-c.disconnected:
+		}
+	}
+}
+
+type pipeConn struct {
+	client *PipeClient
+}
+
+func (p *pipeConn) Send(message packets.Msg) error {
+	select {
+	case p.client.inbox <- &packets.Packet{Msg: message}:
+		return nil
+	case <-p.client.disconnected:
+		return bot.ErrClosed
+	case <-p.client.readerDone:
+		return bot.ErrClosed
+	}
+}
+
+func (p *pipeConn) Receive() (*packets.Packet, error) {
+	packet, ok := <-p.client.outbox
+	if !ok {
+		return nil, bot.ErrClosed
+	}
+	return packet, nil
+}
+
+func (p *pipeConn) Close() error {
+	p.client.disconnectOnce.Do(func() {
+		close(p.client.disconnected)
+	})
+	return nil
+}
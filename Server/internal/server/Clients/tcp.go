@@ -0,0 +1,96 @@
+package clients
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"net"
+
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+// 用长度前缀的 protobuf 直接跑在 TCP 上的客户端，不需要浏览器的可以用它
+type TCPClient struct {
+	baseClient
+	conn net.Conn
+}
+
+func NewTCPClient(hub *server.Hub, conn net.Conn) server.ClientInterfacer {
+	c := &TCPClient{conn: conn}
+	c.baseClient = newBaseClient(hub, c)
+	c.remoteIP = remoteIP(conn.RemoteAddr())
+	return c
+}
+
+// ServeTCP accepts connections on listener and registers a client with the hub for each
+// of them. It returns nil once the listener is closed.
+func ServeTCP(hub *server.Hub, listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		log.Println("New TCP client connected from", conn.RemoteAddr())
+		go hub.Serve(NewTCPClient(hub, conn))
+	}
+}
+
+func (c *TCPClient) ReadPump() {
+	defer func() {
+		c.logger.Printf("Close read pump")
+		c.Close("read pump closed")
+	}()
+
+	reader := bufio.NewReaderSize(c.conn, c.hub.Config.Network.ReadBufferSize)
+
+	for {
+		// 长度不对或者解不开的帧之后就没法再对齐了，直接断开
+		packet, err := packets.ReadFrame(reader, c.hub.Config.Network.MaxFrameSize)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				c.logger.Printf("Error %v", err)
+			}
+			break
+		}
+
+		c.receive(packet)
+	}
+}
+
+func (c *TCPClient) WritePump() {
+	defer func() {
+		c.logger.Println("Closing Write Pump")
+		c.Close("write pump closed")
+		c.conn.Close()
+	}()
+
+	writer := bufio.NewWriterSize(c.conn, c.hub.Config.Network.WriteBufferSize)
+
+	// sendChan 关闭之后会先把剩下的包写完
+	for packet := range c.sendChan {
+		if err := packets.WriteFrame(writer, packet); err != nil {
+			c.logger.Printf("error writing %T packet,closing client: %v", packet.Msg, err)
+			return
+		}
+
+		// 队列里没有更多的包时才真正写到连接上
+		if len(c.sendChan) > 0 {
+			continue
+		}
+
+		if err := writer.Flush(); err != nil {
+			c.logger.Printf("error flushing packets,closing client: %v", err)
+			return
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		c.logger.Printf("error flushing packets: %v", err)
+	}
+}
@@ -0,0 +1,422 @@
+package clients
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"server/internal/server"
+	"server/pkg/packets"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// 可靠数据报重发这么多次还没有确认就断开
+	udpMaxResends = 10
+
+	// 最多缓存这么多个提前到达的可靠数据报
+	udpReceiveWindow = 256
+
+	// 读循环交给每个客户端的数据报队列
+	udpInboxSize = 64
+
+	// cookie 在这段时间里生成，下一段时间里也还有效
+	udpCookieWindow = 30 * time.Second
+)
+
+// UDPServer reads every datagram from a single socket and hands it to the client for
+// its source address. A new address first gets a cookie and has to send it back before
+// a client is created and registered with the hub, so a spoofed source address can
+// neither create clients nor make the server send more than it received.
+type UDPServer struct {
+	hub  *server.Hub
+	conn *net.UDPConn
+
+	// cookie 的密钥，每次启动随机生成，服务器不用记住发出去的 cookie
+	cookieSecret []byte
+
+	clients   map[string]*UDPClient
+	accepting bool
+	mux       sync.Mutex
+}
+
+func NewUDPServer(hub *server.Hub, conn *net.UDPConn) *UDPServer {
+	secret := make([]byte, sha256.Size)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Generating UDP cookie secret: %v", err)
+	}
+
+	return &UDPServer{
+		hub:          hub,
+		conn:         conn,
+		cookieSecret: secret,
+		clients:      make(map[string]*UDPClient),
+		accepting:    true,
+	}
+}
+
+// Serve runs the read loop until the socket is closed, then returns nil.
+func (s *UDPServer) Serve() error {
+	buffer := make([]byte, packets.MaxDatagramSize)
+
+	for {
+		n, addr, err := s.conn.ReadFromUDP(buffer)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		datagram, err := packets.DecodeDatagram(buffer[:n])
+		if err != nil {
+			continue
+		}
+
+		// 读缓冲会被下一个数据报覆盖
+		datagram.Payload = append([]byte(nil), datagram.Payload...)
+		s.dispatch(addr, datagram)
+	}
+}
+
+// 停止接受新的地址，已有的客户端不受影响，关闭服务器时在 Hub.Shutdown 之前调用
+func (s *UDPServer) StopAccepting() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.accepting = false
+}
+
+// 所有客户端都断开之后再关闭 socket，它们的 WritePump 还要用它
+func (s *UDPServer) Close() error {
+	return s.conn.Close()
+}
+
+func (s *UDPServer) dispatch(addr *net.UDPAddr, datagram packets.Datagram) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	key := addr.String()
+	client, exists := s.clients[key]
+	if !exists {
+		// 不认识的地址只能请求 cookie 或者带着 cookie 回来，其他的数据报没有意义
+		if !s.accepting || datagram.Flags&packets.DatagramCookie == 0 {
+			return
+		}
+
+		if !s.validCookie(addr, datagram.Payload, time.Now()) {
+			// 回复和请求一样长，伪造的源地址不能用来放大流量
+			if len(datagram.Payload) >= packets.DatagramCookieSize {
+				s.writeTo(addr, packets.Datagram{Flags: packets.DatagramCookie, Payload: s.cookie(addr, time.Now())})
+			}
+			return
+		}
+
+		log.Println("New UDP client connected from", key)
+		client = newUDPClient(s, addr)
+		s.clients[key] = client
+		go s.hub.Serve(client)
+		return
+	}
+
+	select {
+	case client.inbox <- datagram:
+	default:
+		client.logger.Printf("inbox full,droping datagram")
+	}
+}
+
+// 地址和时间段的 HMAC，只有能收到发给这个地址的数据报的人才知道
+func (s *UDPServer) cookieFor(addr *net.UDPAddr, window int64) []byte {
+	mac := hmac.New(sha256.New, s.cookieSecret)
+	binary.Write(mac, binary.BigEndian, window)
+	mac.Write([]byte(addr.String()))
+	return mac.Sum(nil)[:packets.DatagramCookieSize]
+}
+
+func cookieWindow(now time.Time) int64 {
+	return now.UnixNano() / int64(udpCookieWindow)
+}
+
+func (s *UDPServer) cookie(addr *net.UDPAddr, now time.Time) []byte {
+	return s.cookieFor(addr, cookieWindow(now))
+}
+
+// 这段时间或者上一段时间里发给这个地址的 cookie
+func (s *UDPServer) validCookie(addr *net.UDPAddr, cookie []byte, now time.Time) bool {
+	if len(cookie) != packets.DatagramCookieSize {
+		return false
+	}
+
+	window := cookieWindow(now)
+	return hmac.Equal(cookie, s.cookieFor(addr, window)) || hmac.Equal(cookie, s.cookieFor(addr, window-1))
+}
+
+func (s *UDPServer) writeTo(addr *net.UDPAddr, datagram packets.Datagram) {
+	if _, err := s.conn.WriteToUDP(datagram.Encode(), addr); err != nil {
+		log.Printf("Error writing datagram to %s: %v", addr, err)
+	}
+}
+
+// 客户端的 WritePump 退出之后调用，之后同一个地址再发数据会变成新的客户端
+func (s *UDPServer) remove(client *UDPClient) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	key := client.addr.String()
+	if s.clients[key] == client {
+		delete(s.clients, key)
+		close(client.inbox)
+	}
+}
+
+// 一个还没有被确认的可靠数据报
+type unackedDatagram struct {
+	data     []byte
+	sentAt   time.Time
+	attempts int
+}
+
+// 跑在 UDP 上的客户端。快照这种很快就过期的包不保证送达，其余的包通过序号、确认和重发保证
+// 按顺序送达
+type UDPClient struct {
+	baseClient
+	server *UDPServer
+	addr   *net.UDPAddr
+	inbox  chan packets.Datagram
+
+	// 发送方向，ReadPump 收到确认时也会访问
+	sendStateMux      sync.Mutex
+	nextReliableSeq   uint32
+	nextUnreliableSeq uint32
+	unacked           map[uint32]*unackedDatagram
+
+	// 接收方向，只在 ReadPump 里访问
+	expectedReliableSeq uint32
+	outOfOrder          map[uint32]*packets.Packet
+	lastUnreliableSeq   uint32
+}
+
+func newUDPClient(udpServer *UDPServer, addr *net.UDPAddr) *UDPClient {
+	c := &UDPClient{
+		server:              udpServer,
+		addr:                addr,
+		inbox:               make(chan packets.Datagram, udpInboxSize),
+		nextReliableSeq:     1,
+		nextUnreliableSeq:   1,
+		unacked:             make(map[uint32]*unackedDatagram),
+		expectedReliableSeq: 1,
+		outOfOrder:          make(map[uint32]*packets.Packet),
+	}
+	c.baseClient = newBaseClient(udpServer.hub, c)
+	c.remoteIP = remoteIP(addr)
+	return c
+}
+
+// 快照有自己的确认和回退机制，旧的丢了也不用重发
+func isUnreliable(msg packets.Msg) bool {
+	switch msg.(type) {
+	case *packets.Packet_Snapshot, *packets.Packet_SnapshotDelta:
+		return true
+	}
+	return false
+}
+
+func (c *UDPClient) ReadPump() {
+	defer func() {
+		c.logger.Printf("Close read pump")
+		c.Close("read pump closed")
+	}()
+
+	timeout := c.hub.Config.Network.UDPTimeout.Duration()
+	idle := time.NewTimer(timeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case datagram, ok := <-c.inbox:
+			if !ok {
+				return
+			}
+
+			idle.Reset(timeout)
+
+			if datagram.Flags&packets.DatagramClose != 0 {
+				c.logger.Printf("Client closed the connection")
+				return
+			}
+
+			c.handleDatagram(datagram)
+		case <-idle.C:
+			c.logger.Printf("No datagrams for %s", timeout)
+			return
+		}
+	}
+}
+
+func (c *UDPClient) handleDatagram(datagram packets.Datagram) {
+	switch {
+	case datagram.Flags&packets.DatagramAck != 0:
+		c.sendStateMux.Lock()
+		delete(c.unacked, datagram.Sequence)
+		c.sendStateMux.Unlock()
+	case datagram.Flags&(packets.DatagramPing|packets.DatagramCookie) != 0:
+		// 只是为了刷新超时。客户端没收到服务器的第一个包时会再发一次 cookie
+	case datagram.Flags&packets.DatagramReliable != 0:
+		c.handleReliable(datagram)
+	default:
+		// 比已经处理过的更旧的不可靠包直接丢掉
+		if datagram.Sequence <= c.lastUnreliableSeq {
+			return
+		}
+		c.lastUnreliableSeq = datagram.Sequence
+
+		if packet := c.unmarshal(datagram.Payload); packet != nil {
+			c.receive(packet)
+		}
+	}
+}
+
+func (c *UDPClient) handleReliable(datagram packets.Datagram) {
+	seq := datagram.Sequence
+
+	// 重复的数据报也要再确认一次，对方可能没收到上次的确认
+	if seq < c.expectedReliableSeq {
+		c.writeDatagram(packets.Datagram{Flags: packets.DatagramAck, Sequence: seq})
+		return
+	}
+
+	if seq-c.expectedReliableSeq >= udpReceiveWindow {
+		return
+	}
+
+	packet := c.unmarshal(datagram.Payload)
+	if packet == nil {
+		return
+	}
+
+	c.writeDatagram(packets.Datagram{Flags: packets.DatagramAck, Sequence: seq})
+	c.outOfOrder[seq] = packet
+
+	// 把已经连续的包按顺序交给状态机
+	for {
+		next, exists := c.outOfOrder[c.expectedReliableSeq]
+		if !exists {
+			return
+		}
+
+		delete(c.outOfOrder, c.expectedReliableSeq)
+		c.expectedReliableSeq++
+		c.receive(next)
+	}
+}
+
+func (c *UDPClient) unmarshal(payload []byte) *packets.Packet {
+	packet := &packets.Packet{}
+	if err := proto.Unmarshal(payload, packet); err != nil {
+		c.logger.Printf("error unmarshalling data: %v", err)
+		return nil
+	}
+	return packet
+}
+
+func (c *UDPClient) WritePump() {
+	defer func() {
+		c.logger.Println("Closing Write Pump")
+		c.Close("write pump closed")
+		c.server.remove(c)
+	}()
+
+	resendInterval := c.hub.Config.Network.UDPResendInterval.Duration()
+	ticker := time.NewTicker(resendInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		// sendChan 关闭之后会先把剩下的包发完
+		case packet, ok := <-c.sendChan:
+			if !ok {
+				// 还没确认的包不再重发，告诉对方连接已经断开
+				c.writeDatagram(packets.Datagram{Flags: packets.DatagramClose})
+				return
+			}
+
+			c.send(packet)
+		case now := <-ticker.C:
+			if !c.resend(now, resendInterval) {
+				c.logger.Printf("Client stopped acknowledging packets")
+				c.writeDatagram(packets.Datagram{Flags: packets.DatagramClose})
+				return
+			}
+		}
+	}
+}
+
+func (c *UDPClient) send(packet *packets.Packet) {
+	payload, err := proto.Marshal(packet)
+	if err != nil {
+		c.logger.Printf("error marshalling %T packet: %v", packet.Msg, err)
+		return
+	}
+
+	c.sendStateMux.Lock()
+
+	var datagram packets.Datagram
+	if isUnreliable(packet.Msg) {
+		datagram = packets.Datagram{Sequence: c.nextUnreliableSeq, Payload: payload}
+		c.nextUnreliableSeq++
+		c.sendStateMux.Unlock()
+
+		c.writeDatagram(datagram)
+		return
+	}
+
+	datagram = packets.Datagram{Flags: packets.DatagramReliable, Sequence: c.nextReliableSeq, Payload: payload}
+	c.nextReliableSeq++
+
+	data := datagram.Encode()
+	c.unacked[datagram.Sequence] = &unackedDatagram{data: data, sentAt: time.Now(), attempts: 1}
+	c.sendStateMux.Unlock()
+
+	c.write(data)
+}
+
+// 重发超时没确认的数据报，有数据报重发次数用完时返回 false
+func (c *UDPClient) resend(now time.Time, interval time.Duration) bool {
+	c.sendStateMux.Lock()
+	defer c.sendStateMux.Unlock()
+
+	for _, pending := range c.unacked {
+		if now.Sub(pending.sentAt) < interval {
+			continue
+		}
+
+		if pending.attempts > udpMaxResends {
+			return false
+		}
+
+		pending.attempts++
+		pending.sentAt = now
+		c.write(pending.data)
+	}
+
+	return true
+}
+
+func (c *UDPClient) writeDatagram(datagram packets.Datagram) {
+	c.write(datagram.Encode())
+}
+
+// UDPConn 可以在多个协程里同时写
+func (c *UDPClient) write(data []byte) {
+	if _, err := c.server.conn.WriteToUDP(data, c.addr); err != nil {
+		c.logger.Printf("error writing datagram: %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+package clients_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"server/internal/server"
+	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/pkg/packets"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// 等待服务器数据报的时间，和确认没有数据报时等待的时间
+const (
+	waitTimeout = 5 * time.Second
+	quietPeriod = 200 * time.Millisecond
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// 一个 Hub 和一个在回环地址上监听的 UDP 服务器，返回服务器的地址
+func newTestUDPServer(t *testing.T) (*server.Hub, *net.UDPAddr) {
+	t.Helper()
+
+	cfg := config.Default()
+	cfg.Database.Path = filepath.Join(t.TempDir(), "test.sqlite")
+	cfg.Network.UDPResendInterval = config.Duration(20 * time.Millisecond)
+
+	hub := server.NewHub(cfg)
+	go hub.Run()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	udpServer := clients.NewUDPServer(hub, conn)
+	go udpServer.Serve()
+
+	t.Cleanup(func() {
+		udpServer.StopAccepting()
+
+		ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+		defer cancel()
+		if err := hub.Shutdown(ctx, "test finished"); err != nil {
+			t.Errorf("shutting down hub: %v", err)
+		}
+		udpServer.Close()
+	})
+
+	return hub, conn.LocalAddr().(*net.UDPAddr)
+}
+
+func dial(t *testing.T, addr *net.UDPAddr) *net.UDPConn {
+	t.Helper()
+
+	conn, err := net.DialUDP("udp", nil, addr)
+	if err != nil {
+		t.Fatalf("dialing: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func write(t *testing.T, conn *net.UDPConn, datagram packets.Datagram) {
+	t.Helper()
+
+	if _, err := conn.Write(datagram.Encode()); err != nil {
+		t.Fatalf("writing datagram: %v", err)
+	}
+}
+
+// 一个可靠的包
+func writeReliable(t *testing.T, conn *net.UDPConn, sequence uint32, message packets.Msg) {
+	t.Helper()
+
+	payload, err := proto.Marshal(&packets.Packet{Msg: message})
+	if err != nil {
+		t.Fatalf("marshalling: %v", err)
+	}
+	write(t, conn, packets.Datagram{Flags: packets.DatagramReliable, Sequence: sequence, Payload: payload})
+}
+
+// 读下一个数据报，timeout 之内没有时返回 false
+func read(t *testing.T, conn *net.UDPConn, timeout time.Duration) (packets.Datagram, int, bool) {
+	t.Helper()
+
+	buffer := make([]byte, packets.MaxDatagramSize)
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	n, err := conn.Read(buffer)
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return packets.Datagram{}, 0, false
+	}
+	if err != nil {
+		t.Fatalf("reading datagram: %v", err)
+	}
+
+	datagram, err := packets.DecodeDatagram(buffer[:n])
+	if err != nil {
+		t.Fatalf("decoding datagram: %v", err)
+	}
+	return datagram, n, true
+}
+
+func unmarshal(t *testing.T, datagram packets.Datagram) *packets.Packet {
+	t.Helper()
+
+	packet := &packets.Packet{}
+	if err := proto.Unmarshal(datagram.Payload, packet); err != nil {
+		t.Fatalf("unmarshalling: %v", err)
+	}
+	return packet
+}
+
+// 请求 cookie 再带着它回来，返回服务器的第一个包（可靠的 id）
+func connect(t *testing.T, conn *net.UDPConn) packets.Datagram {
+	t.Helper()
+
+	write(t, conn, packets.Datagram{Flags: packets.DatagramCookie, Payload: make([]byte, packets.DatagramCookieSize)})
+	reply, _, ok := read(t, conn, waitTimeout)
+	if !ok || reply.Flags != packets.DatagramCookie {
+		t.Fatalf("no cookie reply (got %+v)", reply)
+	}
+
+	write(t, conn, reply)
+	first, _, ok := read(t, conn, waitTimeout)
+	if !ok {
+		t.Fatal("server never sent the first packet")
+	}
+	if _, isId := unmarshal(t, first).Msg.(*packets.Packet_Id); !isId || first.Flags != packets.DatagramReliable {
+		t.Fatalf("first datagram is %+v, want a reliable id", first)
+	}
+	return first
+}
+
+// 新地址要先拿到 cookie 再带着它回来，在这之前不创建客户端，回复也不比请求长
+func TestUDPCookie(t *testing.T) {
+	tests := []struct {
+		name    string
+		request packets.Datagram
+		// 服务器回复一个新的 cookie
+		wantCookie bool
+	}{
+		{name: "reliable packet", request: packets.Datagram{Flags: packets.DatagramReliable, Sequence: 1, Payload: make([]byte, 32)}},
+		{name: "ping", request: packets.Datagram{Flags: packets.DatagramPing}},
+		{name: "short cookie request", request: packets.Datagram{Flags: packets.DatagramCookie, Payload: make([]byte, packets.DatagramCookieSize-1)}},
+		{name: "cookie request", request: packets.Datagram{Flags: packets.DatagramCookie, Payload: make([]byte, packets.DatagramCookieSize)}, wantCookie: true},
+		{name: "forged cookie", request: packets.Datagram{Flags: packets.DatagramCookie, Payload: bytes.Repeat([]byte{0xAB}, packets.DatagramCookieSize)}, wantCookie: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub, addr := newTestUDPServer(t)
+			conn := dial(t, addr)
+
+			write(t, conn, tt.request)
+			reply, n, ok := read(t, conn, quietPeriod)
+			if ok != tt.wantCookie {
+				t.Fatalf("got reply %v, want %v", ok, tt.wantCookie)
+			}
+			if ok {
+				if reply.Flags != packets.DatagramCookie || len(reply.Payload) != packets.DatagramCookieSize {
+					t.Errorf("got %+v, want a cookie", reply)
+				}
+				if n > len(tt.request.Encode()) {
+					t.Errorf("reply of %d bytes is longer than the %d byte request", n, len(tt.request.Encode()))
+				}
+			}
+			if clients := hub.Clients.Len(); clients != 0 {
+				t.Errorf("%d clients registered without a valid cookie", clients)
+			}
+		})
+	}
+
+	t.Run("cookie from another address", func(t *testing.T) {
+		_, addr := newTestUDPServer(t)
+		alice := dial(t, addr)
+		mallory := dial(t, addr)
+
+		write(t, alice, packets.Datagram{Flags: packets.DatagramCookie, Payload: make([]byte, packets.DatagramCookieSize)})
+		cookie, _, ok := read(t, alice, waitTimeout)
+		if !ok {
+			t.Fatal("no cookie reply")
+		}
+
+		// 别的地址拿来用只会收到自己的新 cookie
+		write(t, mallory, cookie)
+		reply, _, ok := read(t, mallory, waitTimeout)
+		if !ok || reply.Flags != packets.DatagramCookie || bytes.Equal(reply.Payload, cookie.Payload) {
+			t.Fatalf("got %+v, want a different cookie", reply)
+		}
+	})
+
+	t.Run("valid cookie", func(t *testing.T) {
+		hub, addr := newTestUDPServer(t)
+		conn := dial(t, addr)
+		connect(t, conn)
+
+		if clients := hub.Clients.Len(); clients != 1 {
+			t.Errorf("%d clients registered, want 1", clients)
+		}
+	})
+}
+
+// 没有确认的可靠数据报按间隔原样重发，确认之后不再重发，一直不确认就断开
+func TestUDPResend(t *testing.T) {
+	_, addr := newTestUDPServer(t)
+
+	t.Run("acked", func(t *testing.T) {
+		conn := dial(t, addr)
+		first := connect(t, conn)
+
+		resent, _, ok := read(t, conn, waitTimeout)
+		if !ok || resent.Sequence != first.Sequence || !bytes.Equal(resent.Payload, first.Payload) {
+			t.Fatalf("got %+v, want the id resent", resent)
+		}
+
+		write(t, conn, packets.Datagram{Flags: packets.DatagramAck, Sequence: first.Sequence})
+		// 确认之前发出的重发可能还在路上
+		for {
+			datagram, _, ok := read(t, conn, quietPeriod)
+			if !ok {
+				break
+			}
+			if datagram.Sequence != first.Sequence {
+				t.Fatalf("unexpected datagram %+v", datagram)
+			}
+		}
+	})
+
+	t.Run("never acked", func(t *testing.T) {
+		conn := dial(t, addr)
+		first := connect(t, conn)
+
+		resends := 0
+		for {
+			datagram, _, ok := read(t, conn, waitTimeout)
+			if !ok {
+				t.Fatalf("server neither resent nor closed after %d resends", resends)
+			}
+			if datagram.Flags == packets.DatagramClose {
+				break
+			}
+			if datagram.Sequence != first.Sequence {
+				t.Fatalf("unexpected datagram %+v", datagram)
+			}
+			resends++
+		}
+		if resends == 0 {
+			t.Error("server closed without resending")
+		}
+	})
+}
+
+// 服务器确认每个可靠的数据报（重复的也确认），并按序号顺序交给状态机
+func TestUDPAckAndOrdering(t *testing.T) {
+	_, addr := newTestUDPServer(t)
+	conn := dial(t, addr)
+	first := connect(t, conn)
+	write(t, conn, packets.Datagram{Flags: packets.DatagramAck, Sequence: first.Sequence})
+
+	// 房间列表先到，但要等握手交给状态机之后才处理，不会因为没握手被拒绝
+	writeReliable(t, conn, 2, &packets.Packet_RoomListRequest{RoomListRequest: &packets.RoomListRequestMessage{}})
+	writeReliable(t, conn, 1, packets.NewHello(packets.ProtocolVersion, nil))
+	writeReliable(t, conn, 1, packets.NewHello(packets.ProtocolVersion, nil))
+
+	acks := make(map[uint32]int)
+	var received []packets.Msg
+	deadline := time.Now().Add(waitTimeout)
+	for (acks[1] < 2 || acks[2] < 1 || len(received) < 2) && time.Now().Before(deadline) {
+		datagram, _, ok := read(t, conn, waitTimeout)
+		if !ok {
+			break
+		}
+
+		switch {
+		case datagram.Flags == packets.DatagramAck:
+			acks[datagram.Sequence]++
+		case datagram.Flags == packets.DatagramReliable:
+			if datagram.Sequence == first.Sequence {
+				continue
+			}
+			write(t, conn, packets.Datagram{Flags: packets.DatagramAck, Sequence: datagram.Sequence})
+			received = append(received, unmarshal(t, datagram).Msg)
+		}
+	}
+
+	if acks[1] < 2 || acks[2] < 1 {
+		t.Errorf("got acks %v, want sequence 1 acked twice and 2 once", acks)
+	}
+	if len(received) < 2 {
+		t.Fatalf("got %d replies, want the hello and the room list", len(received))
+	}
+	if _, ok := received[0].(*packets.Packet_Hello); !ok {
+		t.Errorf("first reply is %T, want the hello", received[0])
+	}
+	if _, ok := received[1].(*packets.Packet_RoomList); !ok {
+		t.Errorf("second reply is %T, want the room list", received[1])
+	}
+}
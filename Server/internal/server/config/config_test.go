@@ -0,0 +1,286 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"server/pkg/packets"
+)
+
+// 把 JSON 写进临时文件，返回路径
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		json  string
+		env   map[string]string
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Port != 8080 || cfg.World.MaxSpores != 1000 || cfg.World.TickInterval.Duration() != 50*time.Millisecond {
+					t.Errorf("got port %d, max_spores %d, tick_interval %s", cfg.Port, cfg.World.MaxSpores, cfg.World.TickInterval.Duration())
+				}
+			},
+		},
+		{
+			name: "file overrides defaults",
+			json: `{"port": 9000, "world": {"max_spores": 50, "tick_interval": "20ms", "spectate_on_death": true}}`,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Port != 9000 || cfg.World.MaxSpores != 50 || cfg.World.TickInterval.Duration() != 20*time.Millisecond || !cfg.World.SpectateOnDeath {
+					t.Errorf("got port %d, max_spores %d, tick_interval %s, spectate_on_death %t",
+						cfg.Port, cfg.World.MaxSpores, cfg.World.TickInterval.Duration(), cfg.World.SpectateOnDeath)
+				}
+				// 文件里没有写的字段保持默认值
+				if cfg.World.PlayerSpeed != 150 || cfg.Rooms.Capacity != 50 {
+					t.Errorf("got player_speed %f, rooms.capacity %d", cfg.World.PlayerSpeed, cfg.Rooms.Capacity)
+				}
+			},
+		},
+		{
+			name: "environment overrides file",
+			json: `{"port": 9000, "world": {"max_spores": 50, "respawn_delay": "1s"}}`,
+			env: map[string]string{
+				"MMO_PORT":          "9100",
+				"MMO_RESPAWN_DELAY": "250ms",
+				"MMO_PLAYER_SPEED":  "99.5",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Port != 9100 || cfg.World.RespawnDelay.Duration() != 250*time.Millisecond || cfg.World.PlayerSpeed != 99.5 {
+					t.Errorf("got port %d, respawn_delay %s, player_speed %f", cfg.Port, cfg.World.RespawnDelay.Duration(), cfg.World.PlayerSpeed)
+				}
+				if cfg.World.MaxSpores != 50 {
+					t.Errorf("got max_spores %d, want the file's 50", cfg.World.MaxSpores)
+				}
+			},
+		},
+		{
+			name: "environment lists and bools",
+			env: map[string]string{
+				"MMO_BANNED_WORDS":         " foo, ,bar ",
+				"MMO_SPECTATE_ON_DEATH":    "true",
+				"MMO_DUPLICATE_LOGIN":      "reject",
+				"MMO_RATE_LIMIT_ALLOWLIST": "127.0.0.0/8,::1",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if !slices.Equal(cfg.Accounts.BannedWords, []string{"foo", "bar"}) {
+					t.Errorf("got banned_words %q", cfg.Accounts.BannedWords)
+				}
+				if !slices.Equal(cfg.RateLimit.Allowlist, []string{"127.0.0.0/8", "::1"}) {
+					t.Errorf("got rate_limit.allowlist %q", cfg.RateLimit.Allowlist)
+				}
+				if !cfg.World.SpectateOnDeath || cfg.Accounts.DuplicateLogin != "reject" {
+					t.Errorf("got spectate_on_death %t, duplicate_login %q", cfg.World.SpectateOnDeath, cfg.Accounts.DuplicateLogin)
+				}
+			},
+		},
+		{
+			name: "udp limits max_frame_size",
+			json: `{"network": {"udp_port": 9001, "max_frame_size": 100000}}`,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Network.MaxFrameSize != packets.MaxDatagramPayloadSize {
+					t.Errorf("got max_frame_size %d, want %d", cfg.Network.MaxFrameSize, packets.MaxDatagramPayloadSize)
+				}
+			},
+		},
+		{
+			name: "max_frame_size without udp",
+			json: `{"network": {"max_frame_size": 100000}}`,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Network.MaxFrameSize != 100000 {
+					t.Errorf("got max_frame_size %d, want 100000", cfg.Network.MaxFrameSize)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			path := ""
+			if test.json != "" {
+				path = writeConfig(t, test.json)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadExample(t *testing.T) {
+	if _, err := Load(filepath.Join("..", "..", "..", "config.example.json")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "bad int", env: map[string]string{"MMO_PORT": "eighty"}, wantErr: "MMO_PORT"},
+		{name: "bad float", env: map[string]string{"MMO_PLAYER_SPEED": "fast"}, wantErr: "MMO_PLAYER_SPEED"},
+		{name: "bad duration", env: map[string]string{"MMO_TICK_INTERVAL": "50"}, wantErr: "MMO_TICK_INTERVAL"},
+		{name: "bad bool", env: map[string]string{"MMO_SPECTATE_ON_DEATH": "maybe"}, wantErr: "MMO_SPECTATE_ON_DEATH"},
+		{name: "environment fails validation", env: map[string]string{"MMO_PORT": "70000"}, wantErr: "invalid config: port"},
+		{name: "bad json", json: `{"port": `, wantErr: "parsing config file"},
+		{name: "unknown field", json: `{"world": {"max_spore": 10}}`, wantErr: `unknown field "max_spore"`},
+		{name: "trailing data", json: `{"port": 8080} {"port": 9000}`, wantErr: "unexpected data"},
+		{name: "bad json duration", json: `{"world": {"tick_interval": 50}}`, wantErr: "duration must be a string"},
+		{name: "file fails validation", json: `{"rooms": {"capacity": 0}}`, wantErr: "rooms.capacity"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			path := ""
+			if test.json != "" {
+				path = writeConfig(t, test.json)
+			}
+
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("got error %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil || !strings.Contains(err.Error(), "reading config file") {
+		t.Errorf("got error %v for a missing file", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("default config is invalid: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		change  func(cfg *Config)
+		wantErr string
+	}{
+		{"port", func(c *Config) { c.Port = 0 }, "port must be"},
+		{"shutdown timeout", func(c *Config) { c.ShutdownTimeout = 0 }, "shutdown_timeout"},
+		{"sqlite path", func(c *Config) { c.Database.Path = "" }, "database.path"},
+		{"postgres url", func(c *Config) { c.Database.Driver = "postgres" }, "database.url"},
+		{"database driver", func(c *Config) { c.Database.Driver = "mysql" }, "database.driver"},
+
+		{"max spores", func(c *Config) { c.World.MaxSpores = -1 }, "world.max_spores"},
+		{"spawn bound", func(c *Config) { c.World.SpawnBound = 0 }, "world.spawn_bound"},
+		{"player speed", func(c *Config) { c.World.PlayerSpeed = 0 }, "world.player_speed"},
+		{"player radius", func(c *Config) { c.World.PlayerRadius = -1 }, "world.player_radius"},
+		{"tick interval", func(c *Config) { c.World.TickInterval = 0 }, "world.tick_interval"},
+		{"replenish interval", func(c *Config) { c.World.ReplenishInterval = 0 }, "world.replenish_interval"},
+		{"replenish wave size", func(c *Config) { c.World.ReplenishWaveSize = 0 }, "world.replenish_wave_size"},
+		{"view radius", func(c *Config) { c.World.ViewRadius = 0 }, "world.view_radius must"},
+		{"view radius scale", func(c *Config) { c.World.ViewRadiusScale = -1 }, "world.view_radius_scale"},
+		{"leaderboard interval", func(c *Config) { c.World.LeaderboardInterval = 0 }, "world.leaderboard_interval"},
+		{"leaderboard size", func(c *Config) { c.World.LeaderboardSize = 0 }, "world.leaderboard_size"},
+		{"respawn delay", func(c *Config) { c.World.RespawnDelay = Duration(-time.Second) }, "world.respawn_delay"},
+
+		{"read buffer size", func(c *Config) { c.Network.ReadBufferSize = 0 }, "network.read_buffer_size"},
+		{"write buffer size", func(c *Config) { c.Network.WriteBufferSize = 0 }, "network.write_buffer_size"},
+		{"send queue size", func(c *Config) { c.Network.SendQueueSize = 0 }, "network.send_queue_size"},
+		{"max frame size", func(c *Config) { c.Network.MaxFrameSize = 512 }, "network.max_frame_size"},
+		{"session grace period", func(c *Config) { c.Network.SessionGracePeriod = Duration(-time.Second) }, "network.session_grace_period"},
+		{"tcp port", func(c *Config) { c.Network.TCPPort = 70000 }, "network.tcp_port"},
+		{"udp port", func(c *Config) { c.Network.UDPPort = -1 }, "network.udp_port"},
+		{"udp timeout", func(c *Config) { c.Network.UDPTimeout = 0 }, "network.udp_timeout"},
+		{"udp resend interval", func(c *Config) { c.Network.UDPResendInterval = 0 }, "network.udp_resend_interval"},
+
+		{"username min length", func(c *Config) { c.Accounts.UsernameMinLength = 0 }, "accounts.username_min_length"},
+		{"username max length", func(c *Config) { c.Accounts.UsernameMaxLength = 2 }, "accounts.username_max_length"},
+		{"username pattern", func(c *Config) { c.Accounts.UsernamePattern = "[a-" }, "accounts.username_pattern"},
+		{"duplicate login", func(c *Config) { c.Accounts.DuplicateLogin = "both" }, "accounts.duplicate_login"},
+		{"min password length", func(c *Config) { c.Accounts.MinPasswordLength = 0 }, "accounts.min_password_length"},
+		{"min password classes", func(c *Config) { c.Accounts.MinPasswordClasses = 5 }, "accounts.min_password_classes"},
+
+		{"login attempts", func(c *Config) { c.RateLimit.LoginAttempts = -1 }, "rate_limit.login_attempts"},
+		{"login lockout attempts", func(c *Config) { c.RateLimit.LoginLockoutAttempts = c.RateLimit.LoginAttempts }, "rate_limit.login_lockout_attempts"},
+		{"register attempts", func(c *Config) { c.RateLimit.RegisterAttempts = -1 }, "rate_limit.register_attempts"},
+		{"register lockout attempts", func(c *Config) { c.RateLimit.RegisterLockoutAttempts = 0 }, "rate_limit.register_lockout_attempts"},
+		{"backoff", func(c *Config) { c.RateLimit.Backoff = 0 }, "rate_limit.backoff"},
+		{"max backoff", func(c *Config) { c.RateLimit.MaxBackoff = Duration(time.Millisecond) }, "rate_limit.max_backoff"},
+		{"lockout duration", func(c *Config) { c.RateLimit.LockoutDuration = 0 }, "rate_limit.lockout_duration"},
+		{"reset after", func(c *Config) { c.RateLimit.ResetAfter = 0 }, "rate_limit.reset_after"},
+		{"rate limit allowlist", func(c *Config) { c.RateLimit.Allowlist = []string{"127.0.0.1", "localhost"} }, "rate_limit.allowlist"},
+
+		{"room capacity", func(c *Config) { c.Rooms.Capacity = 0 }, "rooms.capacity"},
+		{"initial rooms", func(c *Config) { c.Rooms.Initial = -1 }, "rooms.initial"},
+		{"max rooms", func(c *Config) { c.Rooms.MaxRooms = 0 }, "rooms.max_rooms"},
+		{"max rooms below initial", func(c *Config) { c.Rooms.Initial = 4; c.Rooms.MaxRooms = 2 }, "rooms.max_rooms"},
+
+		{"target size", func(c *Config) { c.Matchmaking.TargetSize = 0 }, "matchmaking.target_size"},
+		{"target size above capacity", func(c *Config) { c.Matchmaking.TargetSize = c.Rooms.Capacity + 1 }, "matchmaking.target_size"},
+		{"match interval", func(c *Config) { c.Matchmaking.Interval = 0 }, "matchmaking.interval"},
+		{"rating spread", func(c *Config) { c.Matchmaking.RatingSpread = -1 }, "matchmaking.rating_spread must"},
+		{"rating spread per second", func(c *Config) { c.Matchmaking.RatingSpreadPerSecond = -1 }, "matchmaking.rating_spread_per_second"},
+		{"max rating spread", func(c *Config) { c.Matchmaking.MaxRatingSpread = c.Matchmaking.RatingSpread - 1 }, "matchmaking.max_rating_spread"},
+		{"max wait", func(c *Config) { c.Matchmaking.MaxWait = Duration(-time.Second) }, "matchmaking.max_wait"},
+		{"rating per kill", func(c *Config) { c.Matchmaking.RatingPerKill = -1 }, "matchmaking.rating_per_kill"},
+		{"rating per death", func(c *Config) { c.Matchmaking.RatingPerDeath = -1 }, "matchmaking.rating_per_death"},
+
+		{"max friends", func(c *Config) { c.Lobby.MaxFriends = -1 }, "lobby.max_friends"},
+
+		{"bot count", func(c *Config) { c.Bots.Count = -1 }, "bots.count"},
+		{"bot name prefix", func(c *Config) { c.Bots.Count = 1; c.Bots.Password = "secret"; c.Bots.NamePrefix = "" }, "bots.name_prefix"},
+		{"bot password", func(c *Config) { c.Bots.Count = 1 }, "bots.password"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := Default()
+			test.change(cfg)
+
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("got error %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+// 所有的错误一起返回，不在第一个错误就停下
+func TestValidateReportsEveryError(t *testing.T) {
+	cfg := Default()
+	cfg.Port = 0
+	cfg.World.TickInterval = 0
+	cfg.Lobby.MaxFriends = -1
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	for _, want := range []string{"port", "world.tick_interval", "lobby.max_friends"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
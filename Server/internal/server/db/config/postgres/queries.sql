@@ -0,0 +1,126 @@
+-- name: GetUserByUsername :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1;
+
+-- name: CreateUser :one
+INSERT INTO users (
+    username, password_hash
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = $1
+WHERE id = $2;
+
+-- name: AdjustUserRating :exec
+UPDATE users SET rating = GREATEST(rating + sqlc.arg(delta)::BIGINT, 0)
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateUserProfile :exec
+UPDATE users SET nickname = $1, color = $2
+WHERE id = $3;
+
+-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = $1;
+
+-- name: RecordPlayerStats :exec
+INSERT INTO player_stats (
+    user_id, games_played, peak_radius, spores_eaten, players_consumed, time_alive_ms, deaths
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    games_played = player_stats.games_played + excluded.games_played,
+    peak_radius = GREATEST(player_stats.peak_radius, excluded.peak_radius),
+    spores_eaten = player_stats.spores_eaten + excluded.spores_eaten,
+    players_consumed = player_stats.players_consumed + excluded.players_consumed,
+    time_alive_ms = player_stats.time_alive_ms + excluded.time_alive_ms,
+    deaths = player_stats.deaths + excluded.deaths;
+
+-- name: RecordDailyStats :exec
+INSERT INTO daily_stats (
+    user_id, day, games_played, peak_radius, spores_eaten, players_consumed, time_alive_ms, deaths
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (user_id, day) DO UPDATE SET
+    games_played = daily_stats.games_played + excluded.games_played,
+    peak_radius = GREATEST(daily_stats.peak_radius, excluded.peak_radius),
+    spores_eaten = daily_stats.spores_eaten + excluded.spores_eaten,
+    players_consumed = daily_stats.players_consumed + excluded.players_consumed,
+    time_alive_ms = daily_stats.time_alive_ms + excluded.time_alive_ms,
+    deaths = daily_stats.deaths + excluded.deaths;
+
+-- name: DeletePlayerStats :exec
+DELETE FROM player_stats
+WHERE user_id = $1;
+
+-- name: DeleteDailyStats :exec
+DELETE FROM daily_stats
+WHERE user_id = $1;
+
+-- name: GetPlayerStats :one
+SELECT * FROM player_stats
+WHERE user_id = $1 LIMIT 1;
+
+-- name: GetLeaderboard :many
+SELECT users.username, player_stats.peak_radius, player_stats.spores_eaten, player_stats.players_consumed
+FROM player_stats
+JOIN users ON users.id = player_stats.user_id
+ORDER BY player_stats.peak_radius DESC, users.username
+LIMIT $1;
+
+-- name: GetDailyLeaderboard :many
+SELECT users.username, daily_stats.peak_radius, daily_stats.spores_eaten, daily_stats.players_consumed
+FROM daily_stats
+JOIN users ON users.id = daily_stats.user_id
+WHERE daily_stats.day = $1
+ORDER BY daily_stats.peak_radius DESC, users.username
+LIMIT $2;
+
+-- name: AddFriend :exec
+INSERT INTO friends (
+    user_id, friend_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveFriend :exec
+DELETE FROM friends
+WHERE user_id = $1 AND friend_id = $2;
+
+-- name: DeleteFriends :exec
+DELETE FROM friends
+WHERE user_id = $1 OR friend_id = $2;
+
+-- name: GetFriends :many
+SELECT users.id, users.username, users.nickname
+FROM friends
+JOIN users ON users.id = friends.friend_id
+WHERE friends.user_id = $1
+ORDER BY users.username;
+
+-- name: GetNameOwner :one
+SELECT user_id FROM user_names
+WHERE normalized_name = $1 LIMIT 1;
+
+-- name: SetUserName :exec
+INSERT INTO user_names (
+    normalized_name, user_id, kind
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id, kind) DO UPDATE SET
+    normalized_name = excluded.normalized_name;
+
+-- name: DeleteUserName :exec
+DELETE FROM user_names
+WHERE user_id = $1 AND kind = $2;
+
+-- name: DeleteUserNames :exec
+DELETE FROM user_names
+WHERE user_id = $1;
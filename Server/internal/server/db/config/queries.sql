@@ -1,11 +0,0 @@
--- name: GetUserByUsername :one
-SELECT * FROM users
-WHERE username = ? LIMIT 1;
-
--- name: CreateUser :one
-INSERT INTO users (
-    username, password_hash
-) VALUES (
-    ?, ?
-)
-RETURNING *;
\ No newline at end of file
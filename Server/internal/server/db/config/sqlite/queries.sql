@@ -0,0 +1,126 @@
+-- name: GetUserByUsername :one
+SELECT * FROM users
+WHERE username = ? LIMIT 1;
+
+-- name: CreateUser :one
+INSERT INTO users (
+    username, password_hash
+) VALUES (
+    ?, ?
+)
+RETURNING *;
+
+-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = ?
+WHERE id = ?;
+
+-- name: AdjustUserRating :exec
+UPDATE users SET rating = MAX(rating + CAST(sqlc.arg(delta) AS INTEGER), 0)
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateUserProfile :exec
+UPDATE users SET nickname = ?, color = ?
+WHERE id = ?;
+
+-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = ?;
+
+-- name: RecordPlayerStats :exec
+INSERT INTO player_stats (
+    user_id, games_played, peak_radius, spores_eaten, players_consumed, time_alive_ms, deaths
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    games_played = games_played + excluded.games_played,
+    peak_radius = MAX(peak_radius, excluded.peak_radius),
+    spores_eaten = spores_eaten + excluded.spores_eaten,
+    players_consumed = players_consumed + excluded.players_consumed,
+    time_alive_ms = time_alive_ms + excluded.time_alive_ms,
+    deaths = deaths + excluded.deaths;
+
+-- name: RecordDailyStats :exec
+INSERT INTO daily_stats (
+    user_id, day, games_played, peak_radius, spores_eaten, players_consumed, time_alive_ms, deaths
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (user_id, day) DO UPDATE SET
+    games_played = games_played + excluded.games_played,
+    peak_radius = MAX(peak_radius, excluded.peak_radius),
+    spores_eaten = spores_eaten + excluded.spores_eaten,
+    players_consumed = players_consumed + excluded.players_consumed,
+    time_alive_ms = time_alive_ms + excluded.time_alive_ms,
+    deaths = deaths + excluded.deaths;
+
+-- name: DeletePlayerStats :exec
+DELETE FROM player_stats
+WHERE user_id = ?;
+
+-- name: DeleteDailyStats :exec
+DELETE FROM daily_stats
+WHERE user_id = ?;
+
+-- name: GetPlayerStats :one
+SELECT * FROM player_stats
+WHERE user_id = ? LIMIT 1;
+
+-- name: GetLeaderboard :many
+SELECT users.username, player_stats.peak_radius, player_stats.spores_eaten, player_stats.players_consumed
+FROM player_stats
+JOIN users ON users.id = player_stats.user_id
+ORDER BY player_stats.peak_radius DESC, users.username
+LIMIT ?;
+
+-- name: GetDailyLeaderboard :many
+SELECT users.username, daily_stats.peak_radius, daily_stats.spores_eaten, daily_stats.players_consumed
+FROM daily_stats
+JOIN users ON users.id = daily_stats.user_id
+WHERE daily_stats.day = ?
+ORDER BY daily_stats.peak_radius DESC, users.username
+LIMIT ?;
+
+-- name: AddFriend :exec
+INSERT INTO friends (
+    user_id, friend_id
+) VALUES (
+    ?, ?
+)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveFriend :exec
+DELETE FROM friends
+WHERE user_id = ? AND friend_id = ?;
+
+-- name: DeleteFriends :exec
+DELETE FROM friends
+WHERE user_id = ? OR friend_id = ?;
+
+-- name: GetFriends :many
+SELECT users.id, users.username, users.nickname
+FROM friends
+JOIN users ON users.id = friends.friend_id
+WHERE friends.user_id = ?
+ORDER BY users.username;
+
+-- name: GetNameOwner :one
+SELECT user_id FROM user_names
+WHERE normalized_name = ? LIMIT 1;
+
+-- name: SetUserName :exec
+INSERT INTO user_names (
+    normalized_name, user_id, kind
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (user_id, kind) DO UPDATE SET
+    normalized_name = excluded.normalized_name;
+
+-- name: DeleteUserName :exec
+DELETE FROM user_names
+WHERE user_id = ? AND kind = ?;
+
+-- name: DeleteUserNames :exec
+DELETE FROM user_names
+WHERE user_id = ?;
@@ -0,0 +1,254 @@
+// Package migrations applies the numbered SQL files embedded next to it to the database.
+//
+// Each supported database has its own directory (sqlite, postgres) with the same
+// migrations written for its dialect. Each schema change is a pair of files
+// NNNN_name.up.sql and NNNN_name.down.sql. Applied
+// versions are recorded in the schema_migrations table; every migration runs in its own
+// transaction together with that record, so a failed migration leaves nothing behind.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+//go:embed sqlite/*.sql postgres/*.sql
+var files embed.FS
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at TEXT NOT NULL
+)`
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// 一个迁移的状态，AppliedAt 为空表示还没有执行
+type Status struct {
+	Migration
+	AppliedAt string
+}
+
+func (s Status) Applied() bool {
+	return s.AppliedAt != ""
+}
+
+// 记录和删除迁移的语句，两种数据库的占位符不一样
+var recordStatements = map[string]struct{ insert, delete string }{
+	"sqlite": {
+		insert: "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+		delete: "DELETE FROM schema_migrations WHERE version = ?",
+	},
+	"postgres": {
+		insert: "INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+		delete: "DELETE FROM schema_migrations WHERE version = $1",
+	},
+}
+
+// Load returns the embedded migrations for driver sorted by version. Every version needs
+// both an up and a down file.
+func Load(driver string) ([]Migration, error) {
+	if _, ok := recordStatements[driver]; !ok {
+		return nil, fmt.Errorf("no migrations for database driver %q", driver)
+	}
+
+	fsys, err := fs.Sub(files, driver)
+	if err != nil {
+		return nil, err
+	}
+	return load(fsys)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file %s does not match NNNN_name.(up|down).sql", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return a.Version - b.Version
+	})
+	return migrations, nil
+}
+
+type Migrator struct {
+	db         *sql.DB
+	driver     string
+	migrations []Migration
+}
+
+// New returns a migrator for the embedded migrations of driver ("sqlite" or "postgres").
+func New(db *sql.DB, driver string) (*Migrator, error) {
+	migrations, err := Load(driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, driver: driver, migrations: migrations}, nil
+}
+
+// 已经执行过的版本和执行时间
+func (m *Migrator) applied(ctx context.Context) (map[int]string, error) {
+	if _, err := m.db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]string)
+	for rows.Next() {
+		var version int
+		var appliedAt string
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// Status lists every known migration and when it was applied. It fails if the database
+// has a version this binary doesn't know about, i.e. it was migrated by a newer server.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		statuses = append(statuses, Status{Migration: migration, AppliedAt: applied[migration.Version]})
+		delete(applied, migration.Version)
+	}
+
+	for version := range applied {
+		return nil, fmt.Errorf("database has unknown migration %d applied - was it migrated by a newer server?", version)
+	}
+
+	return statuses, nil
+}
+
+// Up applies every pending migration in order and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, status := range statuses {
+		if status.Applied() {
+			continue
+		}
+
+		err := m.inTx(ctx, status.Migration.Up, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, recordStatements[m.driver].insert,
+				status.Version, status.Name, time.Now().UTC().Format(time.RFC3339))
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("applying migration %d_%s: %w", status.Version, status.Name, err)
+		}
+		done = append(done, status.Migration)
+	}
+
+	return done, nil
+}
+
+// Down rolls back the last steps applied migrations, newest first, and returns the ones
+// it rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+		status := statuses[i]
+		if !status.Applied() {
+			continue
+		}
+
+		err := m.inTx(ctx, status.Migration.Down, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, recordStatements[m.driver].delete, status.Version)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("rolling back migration %d_%s: %w", status.Version, status.Name, err)
+		}
+		done = append(done, status.Migration)
+	}
+
+	return done, nil
+}
+
+// 在同一个事务里执行迁移的 SQL 和记录
+func (m *Migrator) inTx(ctx context.Context, script string, record func(*sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,255 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+func TestLoad(t *testing.T) {
+	file := func(data string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(data)}
+	}
+
+	tests := []struct {
+		name         string
+		files        fstest.MapFS
+		wantVersions []int
+		wantErr      string
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"0010_b.up.sql":   file("b"),
+				"0010_b.down.sql": file("b"),
+				"0002_a.up.sql":   file("a"),
+				"0002_a.down.sql": file("a"),
+			},
+			wantVersions: []int{2, 10},
+		},
+		{
+			name:    "missing down",
+			files:   fstest.MapFS{"0001_a.up.sql": file("a")},
+			wantErr: "needs both an up and a down file",
+		},
+		{
+			name:    "bad file name",
+			files:   fstest.MapFS{"create_users.sql": file("a")},
+			wantErr: "does not match",
+		},
+		{
+			name: "two names for one version",
+			files: fstest.MapFS{
+				"0001_a.up.sql":   file("a"),
+				"0001_b.down.sql": file("b"),
+			},
+			wantErr: "has two names",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			migrations, err := load(test.files)
+
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("got error %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var versions []int
+			for _, migration := range migrations {
+				versions = append(versions, migration.Version)
+			}
+			if !slices.Equal(versions, test.wantVersions) {
+				t.Fatalf("got versions %v, want %v", versions, test.wantVersions)
+			}
+		})
+	}
+}
+
+// 两种数据库的迁移要一一对应
+func TestDriversMatch(t *testing.T) {
+	sqlite, err := Load("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	postgres, err := Load("postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := func(migration Migration) string {
+		return strconv.Itoa(migration.Version) + "_" + migration.Name
+	}
+	var sqliteNames, postgresNames []string
+	for _, migration := range sqlite {
+		sqliteNames = append(sqliteNames, name(migration))
+	}
+	for _, migration := range postgres {
+		postgresNames = append(postgresNames, name(migration))
+	}
+
+	if !slices.Equal(sqliteNames, postgresNames) {
+		t.Errorf("sqlite has migrations %v, postgres has %v", sqliteNames, postgresNames)
+	}
+}
+
+// PostgreSQL 只有设置了 MMO_TEST_POSTGRES_URL 才测试，需要一个空的数据库
+func TestUpDown(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		dbPool, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.sqlite"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dbPool.Close()
+
+		testUpDown(t, dbPool, "sqlite")
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		url := os.Getenv("MMO_TEST_POSTGRES_URL")
+		if url == "" {
+			t.Skip("MMO_TEST_POSTGRES_URL is not set")
+		}
+
+		dbPool, err := sql.Open("postgres", url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dbPool.Close()
+		defer dbPool.Exec("DROP TABLE IF EXISTS schema_migrations")
+
+		testUpDown(t, dbPool, "postgres")
+	})
+}
+
+func testUpDown(t *testing.T, dbPool *sql.DB, driver string) {
+	ctx := context.Background()
+
+	migrator, err := New(dbPool, driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := len(migrator.migrations)
+
+	applied, err := migrator.Up(ctx)
+	if err != nil || len(applied) != total {
+		t.Fatalf("first up applied %d of %d migrations: %v", len(applied), total, err)
+	}
+
+	// 再执行一次什么都不做
+	if applied, err := migrator.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("second up applied %d migrations: %v", len(applied), err)
+	}
+
+	if _, err := dbPool.ExecContext(ctx, "INSERT INTO users (username, password_hash) VALUES ('alice', 'x')"); err != nil {
+		t.Fatalf("users table missing after up: %v", err)
+	}
+
+	rolledBack, err := migrator.Down(ctx, total+1)
+	if err != nil || len(rolledBack) != total {
+		t.Fatalf("down rolled back %d of %d migrations: %v", len(rolledBack), total, err)
+	}
+	if rolledBack[0].Version != migrator.migrations[total-1].Version {
+		t.Errorf("down started at version %d, want the newest", rolledBack[0].Version)
+	}
+
+	if _, err := dbPool.ExecContext(ctx, "SELECT * FROM users"); err == nil {
+		t.Error("users table still exists after rolling everything back")
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range statuses {
+		if status.Applied() {
+			t.Errorf("migration %d still applied", status.Version)
+		}
+	}
+
+	// 数据库里有更新的服务器执行过的迁移
+	if _, err := dbPool.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (9999, 'future', 'now')"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err == nil || !strings.Contains(err.Error(), "unknown migration 9999") {
+		t.Errorf("got error %v, want unknown migration", err)
+	}
+}
+
+// 0006 把已有账号的名字写进 user_names，长得像的只保留先注册的
+func TestUserNamesBackfill(t *testing.T) {
+	ctx := context.Background()
+
+	dbPool, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbPool.Close()
+
+	migrator, err := New(dbPool, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 回到 0006 之前
+	steps := 0
+	for _, migration := range migrator.migrations {
+		if migration.Version >= 6 {
+			steps++
+		}
+	}
+	if _, err := migrator.Down(ctx, steps); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = dbPool.ExecContext(ctx, `INSERT INTO users (username, password_hash, nickname) VALUES
+		('alice', 'x', ''), ('a1ice', 'x', ''), ('bob', 'x', 'Al_ice'), ('carol', 'x', 'Caro-7')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := dbPool.QueryContext(ctx, "SELECT normalized_name, user_id, kind FROM user_names ORDER BY normalized_name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var name, kind string
+		var userId int64
+		if err := rows.Scan(&name, &userId, &kind); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, name+"/"+strconv.FormatInt(userId, 10)+"/"+kind)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"allce/1/username", "bob/3/username", "carol/4/username", "carot/4/nickname"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got user names %v, want %v", got, want)
+	}
+}
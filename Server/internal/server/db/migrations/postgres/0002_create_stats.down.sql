@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS daily_stats_day_peak_radius;
+DROP TABLE IF EXISTS daily_stats;
+DROP TABLE IF EXISTS player_stats;
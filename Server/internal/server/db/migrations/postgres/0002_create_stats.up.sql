@@ -0,0 +1,25 @@
+-- 每个玩家累计的游戏数据
+CREATE TABLE IF NOT EXISTS player_stats (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    games_played BIGINT NOT NULL DEFAULT 0,
+    peak_radius DOUBLE PRECISION NOT NULL DEFAULT 0,
+    spores_eaten BIGINT NOT NULL DEFAULT 0,
+    players_consumed BIGINT NOT NULL DEFAULT 0,
+    time_alive_ms BIGINT NOT NULL DEFAULT 0,
+    deaths BIGINT NOT NULL DEFAULT 0
+);
+
+-- 按天（UTC，YYYY-MM-DD）累计的游戏数据，用于每日排行榜
+CREATE TABLE IF NOT EXISTS daily_stats (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day TEXT NOT NULL,
+    games_played BIGINT NOT NULL DEFAULT 0,
+    peak_radius DOUBLE PRECISION NOT NULL DEFAULT 0,
+    spores_eaten BIGINT NOT NULL DEFAULT 0,
+    players_consumed BIGINT NOT NULL DEFAULT 0,
+    time_alive_ms BIGINT NOT NULL DEFAULT 0,
+    deaths BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS daily_stats_day_peak_radius ON daily_stats (day, peak_radius DESC);
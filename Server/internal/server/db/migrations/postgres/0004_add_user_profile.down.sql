@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN color;
+ALTER TABLE users DROP COLUMN nickname;
@@ -0,0 +1,6 @@
+-- 好友列表，单向的：user_id 把 friend_id 加为好友
+CREATE TABLE IF NOT EXISTS friends (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    friend_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, friend_id)
+);
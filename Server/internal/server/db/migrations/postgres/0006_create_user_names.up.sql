@@ -0,0 +1,22 @@
+-- 用户名和昵称去掉长得像的字符之后的样子（和 normalizeLookalikes 一样），一个名字只属于一个账号，
+-- 这样昵称不能冒充别的账号的用户名或者昵称。kind 是 username 或者 nickname，每个账号各有一个
+CREATE TABLE IF NOT EXISTS user_names (
+    normalized_name TEXT PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    UNIQUE (user_id, kind)
+);
+
+-- 已有的账号：撞名的只保留先注册的
+INSERT INTO user_names (normalized_name, user_id, kind)
+SELECT REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(LOWER(username), '0', 'o'), '1', 'l'), 'i', 'l'), '3', 'e'), '4', 'a'), '5', 's'), '7', 't'), '8', 'b'), '_', ''), '-', ''), id, 'username'
+FROM users
+ORDER BY id
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_names (normalized_name, user_id, kind)
+SELECT REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(LOWER(nickname), '0', 'o'), '1', 'l'), 'i', 'l'), '3', 'e'), '4', 'a'), '5', 's'), '7', 't'), '8', 'b'), '_', ''), '-', ''), id, 'nickname'
+FROM users
+WHERE nickname != ''
+ORDER BY id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+-- 用 IF NOT EXISTS，之前直接执行 schema.sql 创建的数据库也能接上迁移
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL
+);
@@ -0,0 +1,2 @@
+-- 匹配用的分数，新账号从 1000 开始
+ALTER TABLE users ADD COLUMN rating INTEGER NOT NULL DEFAULT 1000;
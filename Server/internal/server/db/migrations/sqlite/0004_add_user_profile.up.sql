@@ -0,0 +1,3 @@
+-- 大厅里设置的昵称（空表示用用户名）和颜色（0xRRGGBB）
+ALTER TABLE users ADD COLUMN nickname TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN color INTEGER NOT NULL DEFAULT 0;
@@ -4,8 +4,43 @@
 
 package db
 
+type DailyStat struct {
+	UserID          int64
+	Day             string
+	GamesPlayed     int64
+	PeakRadius      float64
+	SporesEaten     int64
+	PlayersConsumed int64
+	TimeAliveMs     int64
+	Deaths          int64
+}
+
+type Friend struct {
+	UserID   int64
+	FriendID int64
+}
+
+type PlayerStat struct {
+	UserID          int64
+	GamesPlayed     int64
+	PeakRadius      float64
+	SporesEaten     int64
+	PlayersConsumed int64
+	TimeAliveMs     int64
+	Deaths          int64
+}
+
 type User struct {
 	ID           int64
 	Username     string
 	PasswordHash string
+	Rating       int64
+	Nickname     string
+	Color        int64
+}
+
+type UserName struct {
+	NormalizedName string
+	UserID         int64
+	Kind           string
 }
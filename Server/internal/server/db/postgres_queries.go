@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+
+	"server/internal/server/db/postgres"
+)
+
+// postgresQueries 让 PostgreSQL 的 sqlc 代码实现 Querier。两边的行结构一样，直接转换类型
+type postgresQueries struct {
+	queries *postgres.Queries
+}
+
+func newPostgresQueries(db postgres.DBTX) *postgresQueries {
+	return &postgresQueries{queries: postgres.New(db)}
+}
+
+var _ Querier = (*postgresQueries)(nil)
+
+func (q *postgresQueries) AddFriend(ctx context.Context, arg AddFriendParams) error {
+	return q.queries.AddFriend(ctx, postgres.AddFriendParams(arg))
+}
+
+func (q *postgresQueries) AdjustUserRating(ctx context.Context, arg AdjustUserRatingParams) error {
+	return q.queries.AdjustUserRating(ctx, postgres.AdjustUserRatingParams(arg))
+}
+
+func (q *postgresQueries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	user, err := q.queries.CreateUser(ctx, postgres.CreateUserParams(arg))
+	return User(user), err
+}
+
+func (q *postgresQueries) DeleteDailyStats(ctx context.Context, userID int64) error {
+	return q.queries.DeleteDailyStats(ctx, userID)
+}
+
+func (q *postgresQueries) DeleteFriends(ctx context.Context, arg DeleteFriendsParams) error {
+	return q.queries.DeleteFriends(ctx, postgres.DeleteFriendsParams(arg))
+}
+
+func (q *postgresQueries) DeletePlayerStats(ctx context.Context, userID int64) error {
+	return q.queries.DeletePlayerStats(ctx, userID)
+}
+
+func (q *postgresQueries) DeleteUser(ctx context.Context, id int64) error {
+	return q.queries.DeleteUser(ctx, id)
+}
+
+func (q *postgresQueries) DeleteUserName(ctx context.Context, arg DeleteUserNameParams) error {
+	return q.queries.DeleteUserName(ctx, postgres.DeleteUserNameParams(arg))
+}
+
+func (q *postgresQueries) DeleteUserNames(ctx context.Context, userID int64) error {
+	return q.queries.DeleteUserNames(ctx, userID)
+}
+
+func (q *postgresQueries) GetDailyLeaderboard(ctx context.Context, arg GetDailyLeaderboardParams) ([]GetDailyLeaderboardRow, error) {
+	rows, err := q.queries.GetDailyLeaderboard(ctx, postgres.GetDailyLeaderboardParams{
+		Day:   arg.Day,
+		Limit: int32(arg.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]GetDailyLeaderboardRow, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, GetDailyLeaderboardRow(row))
+	}
+	return items, nil
+}
+
+func (q *postgresQueries) GetFriends(ctx context.Context, userID int64) ([]GetFriendsRow, error) {
+	rows, err := q.queries.GetFriends(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]GetFriendsRow, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, GetFriendsRow(row))
+	}
+	return items, nil
+}
+
+func (q *postgresQueries) GetLeaderboard(ctx context.Context, limit int64) ([]GetLeaderboardRow, error) {
+	rows, err := q.queries.GetLeaderboard(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]GetLeaderboardRow, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, GetLeaderboardRow(row))
+	}
+	return items, nil
+}
+
+func (q *postgresQueries) GetNameOwner(ctx context.Context, normalizedName string) (int64, error) {
+	return q.queries.GetNameOwner(ctx, normalizedName)
+}
+
+func (q *postgresQueries) GetPlayerStats(ctx context.Context, userID int64) (PlayerStat, error) {
+	stats, err := q.queries.GetPlayerStats(ctx, userID)
+	return PlayerStat(stats), err
+}
+
+func (q *postgresQueries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	user, err := q.queries.GetUserByUsername(ctx, username)
+	return User(user), err
+}
+
+func (q *postgresQueries) RecordDailyStats(ctx context.Context, arg RecordDailyStatsParams) error {
+	return q.queries.RecordDailyStats(ctx, postgres.RecordDailyStatsParams(arg))
+}
+
+func (q *postgresQueries) RecordPlayerStats(ctx context.Context, arg RecordPlayerStatsParams) error {
+	return q.queries.RecordPlayerStats(ctx, postgres.RecordPlayerStatsParams(arg))
+}
+
+func (q *postgresQueries) RemoveFriend(ctx context.Context, arg RemoveFriendParams) error {
+	return q.queries.RemoveFriend(ctx, postgres.RemoveFriendParams(arg))
+}
+
+func (q *postgresQueries) SetUserName(ctx context.Context, arg SetUserNameParams) error {
+	return q.queries.SetUserName(ctx, postgres.SetUserNameParams(arg))
+}
+
+func (q *postgresQueries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	return q.queries.UpdateUserPassword(ctx, postgres.UpdateUserPasswordParams(arg))
+}
+
+func (q *postgresQueries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) error {
+	return q.queries.UpdateUserProfile(ctx, postgres.UpdateUserProfileParams(arg))
+}
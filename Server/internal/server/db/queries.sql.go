@@ -9,13 +9,47 @@ import (
 	"context"
 )
 
+const addFriend = `-- name: AddFriend :exec
+INSERT INTO friends (
+    user_id, friend_id
+) VALUES (
+    ?, ?
+)
+ON CONFLICT DO NOTHING
+`
+
+type AddFriendParams struct {
+	UserID   int64
+	FriendID int64
+}
+
+func (q *Queries) AddFriend(ctx context.Context, arg AddFriendParams) error {
+	_, err := q.db.ExecContext(ctx, addFriend, arg.UserID, arg.FriendID)
+	return err
+}
+
+const adjustUserRating = `-- name: AdjustUserRating :exec
+UPDATE users SET rating = MAX(rating + CAST(? AS INTEGER), 0)
+WHERE id = ?
+`
+
+type AdjustUserRatingParams struct {
+	Delta int64
+	ID    int64
+}
+
+func (q *Queries) AdjustUserRating(ctx context.Context, arg AdjustUserRatingParams) error {
+	_, err := q.db.ExecContext(ctx, adjustUserRating, arg.Delta, arg.ID)
+	return err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     username, password_hash
 ) VALUES (
     ?, ?
 )
-RETURNING id, username, password_hash
+RETURNING id, username, password_hash, rating, nickname, color
 `
 
 type CreateUserParams struct {
@@ -26,18 +60,408 @@ type CreateUserParams struct {
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
 	row := q.db.QueryRowContext(ctx, createUser, arg.Username, arg.PasswordHash)
 	var i User
-	err := row.Scan(&i.ID, &i.Username, &i.PasswordHash)
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Rating,
+		&i.Nickname,
+		&i.Color,
+	)
+	return i, err
+}
+
+const deleteDailyStats = `-- name: DeleteDailyStats :exec
+DELETE FROM daily_stats
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteDailyStats(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteDailyStats, userID)
+	return err
+}
+
+const deleteFriends = `-- name: DeleteFriends :exec
+DELETE FROM friends
+WHERE user_id = ? OR friend_id = ?
+`
+
+type DeleteFriendsParams struct {
+	UserID   int64
+	FriendID int64
+}
+
+func (q *Queries) DeleteFriends(ctx context.Context, arg DeleteFriendsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFriends, arg.UserID, arg.FriendID)
+	return err
+}
+
+const deletePlayerStats = `-- name: DeletePlayerStats :exec
+DELETE FROM player_stats
+WHERE user_id = ?
+`
+
+func (q *Queries) DeletePlayerStats(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerStats, userID)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = ?
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUser, id)
+	return err
+}
+
+const deleteUserName = `-- name: DeleteUserName :exec
+DELETE FROM user_names
+WHERE user_id = ? AND kind = ?
+`
+
+type DeleteUserNameParams struct {
+	UserID int64
+	Kind   string
+}
+
+func (q *Queries) DeleteUserName(ctx context.Context, arg DeleteUserNameParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserName, arg.UserID, arg.Kind)
+	return err
+}
+
+const deleteUserNames = `-- name: DeleteUserNames :exec
+DELETE FROM user_names
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteUserNames(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserNames, userID)
+	return err
+}
+
+const getDailyLeaderboard = `-- name: GetDailyLeaderboard :many
+SELECT users.username, daily_stats.peak_radius, daily_stats.spores_eaten, daily_stats.players_consumed
+FROM daily_stats
+JOIN users ON users.id = daily_stats.user_id
+WHERE daily_stats.day = ?
+ORDER BY daily_stats.peak_radius DESC, users.username
+LIMIT ?
+`
+
+type GetDailyLeaderboardParams struct {
+	Day   string
+	Limit int64
+}
+
+type GetDailyLeaderboardRow struct {
+	Username        string
+	PeakRadius      float64
+	SporesEaten     int64
+	PlayersConsumed int64
+}
+
+func (q *Queries) GetDailyLeaderboard(ctx context.Context, arg GetDailyLeaderboardParams) ([]GetDailyLeaderboardRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDailyLeaderboard, arg.Day, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDailyLeaderboardRow
+	for rows.Next() {
+		var i GetDailyLeaderboardRow
+		if err := rows.Scan(
+			&i.Username,
+			&i.PeakRadius,
+			&i.SporesEaten,
+			&i.PlayersConsumed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFriends = `-- name: GetFriends :many
+SELECT users.id, users.username, users.nickname
+FROM friends
+JOIN users ON users.id = friends.friend_id
+WHERE friends.user_id = ?
+ORDER BY users.username
+`
+
+type GetFriendsRow struct {
+	ID       int64
+	Username string
+	Nickname string
+}
+
+func (q *Queries) GetFriends(ctx context.Context, userID int64) ([]GetFriendsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFriends, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFriendsRow
+	for rows.Next() {
+		var i GetFriendsRow
+		if err := rows.Scan(&i.ID, &i.Username, &i.Nickname); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLeaderboard = `-- name: GetLeaderboard :many
+SELECT users.username, player_stats.peak_radius, player_stats.spores_eaten, player_stats.players_consumed
+FROM player_stats
+JOIN users ON users.id = player_stats.user_id
+ORDER BY player_stats.peak_radius DESC, users.username
+LIMIT ?
+`
+
+type GetLeaderboardRow struct {
+	Username        string
+	PeakRadius      float64
+	SporesEaten     int64
+	PlayersConsumed int64
+}
+
+func (q *Queries) GetLeaderboard(ctx context.Context, limit int64) ([]GetLeaderboardRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLeaderboard, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLeaderboardRow
+	for rows.Next() {
+		var i GetLeaderboardRow
+		if err := rows.Scan(
+			&i.Username,
+			&i.PeakRadius,
+			&i.SporesEaten,
+			&i.PlayersConsumed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNameOwner = `-- name: GetNameOwner :one
+SELECT user_id FROM user_names
+WHERE normalized_name = ? LIMIT 1
+`
+
+func (q *Queries) GetNameOwner(ctx context.Context, normalizedName string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getNameOwner, normalizedName)
+	var user_id int64
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
+const getPlayerStats = `-- name: GetPlayerStats :one
+SELECT user_id, games_played, peak_radius, spores_eaten, players_consumed, time_alive_ms, deaths FROM player_stats
+WHERE user_id = ? LIMIT 1
+`
+
+func (q *Queries) GetPlayerStats(ctx context.Context, userID int64) (PlayerStat, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerStats, userID)
+	var i PlayerStat
+	err := row.Scan(
+		&i.UserID,
+		&i.GamesPlayed,
+		&i.PeakRadius,
+		&i.SporesEaten,
+		&i.PlayersConsumed,
+		&i.TimeAliveMs,
+		&i.Deaths,
+	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, password_hash FROM users
+SELECT id, username, password_hash, rating, nickname, color FROM users
 WHERE username = ? LIMIT 1
 `
 
 func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
 	row := q.db.QueryRowContext(ctx, getUserByUsername, username)
 	var i User
-	err := row.Scan(&i.ID, &i.Username, &i.PasswordHash)
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+		&i.Rating,
+		&i.Nickname,
+		&i.Color,
+	)
 	return i, err
 }
+
+const recordDailyStats = `-- name: RecordDailyStats :exec
+INSERT INTO daily_stats (
+    user_id, day, games_played, peak_radius, spores_eaten, players_consumed, time_alive_ms, deaths
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (user_id, day) DO UPDATE SET
+    games_played = games_played + excluded.games_played,
+    peak_radius = MAX(peak_radius, excluded.peak_radius),
+    spores_eaten = spores_eaten + excluded.spores_eaten,
+    players_consumed = players_consumed + excluded.players_consumed,
+    time_alive_ms = time_alive_ms + excluded.time_alive_ms,
+    deaths = deaths + excluded.deaths
+`
+
+type RecordDailyStatsParams struct {
+	UserID          int64
+	Day             string
+	GamesPlayed     int64
+	PeakRadius      float64
+	SporesEaten     int64
+	PlayersConsumed int64
+	TimeAliveMs     int64
+	Deaths          int64
+}
+
+func (q *Queries) RecordDailyStats(ctx context.Context, arg RecordDailyStatsParams) error {
+	_, err := q.db.ExecContext(ctx, recordDailyStats,
+		arg.UserID,
+		arg.Day,
+		arg.GamesPlayed,
+		arg.PeakRadius,
+		arg.SporesEaten,
+		arg.PlayersConsumed,
+		arg.TimeAliveMs,
+		arg.Deaths,
+	)
+	return err
+}
+
+const recordPlayerStats = `-- name: RecordPlayerStats :exec
+INSERT INTO player_stats (
+    user_id, games_played, peak_radius, spores_eaten, players_consumed, time_alive_ms, deaths
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    games_played = games_played + excluded.games_played,
+    peak_radius = MAX(peak_radius, excluded.peak_radius),
+    spores_eaten = spores_eaten + excluded.spores_eaten,
+    players_consumed = players_consumed + excluded.players_consumed,
+    time_alive_ms = time_alive_ms + excluded.time_alive_ms,
+    deaths = deaths + excluded.deaths
+`
+
+type RecordPlayerStatsParams struct {
+	UserID          int64
+	GamesPlayed     int64
+	PeakRadius      float64
+	SporesEaten     int64
+	PlayersConsumed int64
+	TimeAliveMs     int64
+	Deaths          int64
+}
+
+func (q *Queries) RecordPlayerStats(ctx context.Context, arg RecordPlayerStatsParams) error {
+	_, err := q.db.ExecContext(ctx, recordPlayerStats,
+		arg.UserID,
+		arg.GamesPlayed,
+		arg.PeakRadius,
+		arg.SporesEaten,
+		arg.PlayersConsumed,
+		arg.TimeAliveMs,
+		arg.Deaths,
+	)
+	return err
+}
+
+const removeFriend = `-- name: RemoveFriend :exec
+DELETE FROM friends
+WHERE user_id = ? AND friend_id = ?
+`
+
+type RemoveFriendParams struct {
+	UserID   int64
+	FriendID int64
+}
+
+func (q *Queries) RemoveFriend(ctx context.Context, arg RemoveFriendParams) error {
+	_, err := q.db.ExecContext(ctx, removeFriend, arg.UserID, arg.FriendID)
+	return err
+}
+
+const setUserName = `-- name: SetUserName :exec
+INSERT INTO user_names (
+    normalized_name, user_id, kind
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (user_id, kind) DO UPDATE SET
+    normalized_name = excluded.normalized_name
+`
+
+type SetUserNameParams struct {
+	NormalizedName string
+	UserID         int64
+	Kind           string
+}
+
+func (q *Queries) SetUserName(ctx context.Context, arg SetUserNameParams) error {
+	_, err := q.db.ExecContext(ctx, setUserName, arg.NormalizedName, arg.UserID, arg.Kind)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = ?
+WHERE id = ?
+`
+
+type UpdateUserPasswordParams struct {
+	PasswordHash string
+	ID           int64
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.PasswordHash, arg.ID)
+	return err
+}
+
+const updateUserProfile = `-- name: UpdateUserProfile :exec
+UPDATE users SET nickname = ?, color = ?
+WHERE id = ?
+`
+
+type UpdateUserProfileParams struct {
+	Nickname string
+	Color    int64
+	ID       int64
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserProfile, arg.Nickname, arg.Color, arg.ID)
+	return err
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"server/internal/server/config"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// SQLite 等锁的时间，超过之后才返回 SQLITE_BUSY
+const sqliteBusyTimeoutMs = 5000
+
+// 支持的数据库，和 database.driver 的值一样
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// Store is the storage backend the server runs against: the connection pool and the
+// queries written for its SQL dialect.
+type Store struct {
+	DB      *sql.DB
+	Driver  string
+	Queries Querier
+}
+
+// Open connects to the database selected by cfg. SQLite uses the file at cfg.Path,
+// PostgreSQL the connection string in cfg.URL.
+func Open(cfg config.DatabaseConfig) (*Store, error) {
+	switch cfg.Driver {
+	case DriverSQLite:
+		dbPool, err := sql.Open("sqlite", sqliteDSN(cfg.Path))
+		if err != nil {
+			return nil, err
+		}
+		return &Store{DB: dbPool, Driver: cfg.Driver, Queries: New(dbPool)}, nil
+	case DriverPostgres:
+		dbPool, err := sql.Open("postgres", cfg.URL)
+		if err != nil {
+			return nil, err
+		}
+		return &Store{DB: dbPool, Driver: cfg.Driver, Queries: newPostgresQueries(dbPool)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+}
+
+// 连接池里的每个连接都要设置：统计和账号在不同的协程里同时写，等锁而不是马上返回 SQLITE_BUSY；
+// WAL 让读和写不互相阻塞。路径里已经设置了的 pragma 不覆盖
+func sqliteDSN(path string) string {
+	var pragmas []string
+	if !strings.Contains(path, "busy_timeout") {
+		pragmas = append(pragmas, fmt.Sprintf("_pragma=busy_timeout(%d)", sqliteBusyTimeoutMs))
+	}
+	if !strings.Contains(path, "journal_mode") {
+		pragmas = append(pragmas, "_pragma=journal_mode(WAL)")
+	}
+	if len(pragmas) == 0 {
+		return path
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + strings.Join(pragmas, "&")
+}
+
+// 在一个事务里执行 fn，fn 拿到的 Querier 的查询都在这个事务里。fn 返回错误时回滚
+func (s *Store) InTx(ctx context.Context, fn func(queries Querier) error) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var queries Querier
+	switch s.Driver {
+	case DriverPostgres:
+		queries = newPostgresQueries(tx)
+	default:
+		queries = New(tx)
+	}
+
+	if err := fn(queries); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) Close() error {
+	return s.DB.Close()
+}
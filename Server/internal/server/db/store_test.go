@@ -0,0 +1,111 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"server/internal/server/config"
+	"server/internal/server/db"
+	"server/internal/server/db/migrations"
+)
+
+// 连接池里的每个连接都设置了 busy_timeout 和 WAL
+func TestOpenSQLitePragmas(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		wantBusyTimeout int
+	}{
+		{name: "plain path", wantBusyTimeout: 5000},
+		{name: "path with its own busy_timeout", query: "?_pragma=busy_timeout(100)", wantBusyTimeout: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := db.Open(config.DatabaseConfig{
+				Driver: db.DriverSQLite,
+				Path:   filepath.Join(t.TempDir(), "test.sqlite") + tt.query,
+			})
+			if err != nil {
+				t.Fatalf("opening database: %v", err)
+			}
+			defer store.Close()
+
+			// 占住一个连接，下面的查询一定用的是另一个新连接
+			held, err := store.DB.Conn(context.Background())
+			if err != nil {
+				t.Fatalf("getting connection: %v", err)
+			}
+			defer held.Close()
+
+			var journalMode string
+			if err := store.DB.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+				t.Fatalf("reading journal_mode: %v", err)
+			}
+			if journalMode != "wal" {
+				t.Errorf("journal_mode = %q, want wal", journalMode)
+			}
+
+			var busyTimeout int
+			if err := store.DB.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+				t.Fatalf("reading busy_timeout: %v", err)
+			}
+			if busyTimeout != tt.wantBusyTimeout {
+				t.Errorf("busy_timeout = %d, want %d", busyTimeout, tt.wantBusyTimeout)
+			}
+		})
+	}
+}
+
+// 事务里的函数返回错误时，之前的修改都回滚
+func TestInTx(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.Open(config.DatabaseConfig{
+		Driver: db.DriverSQLite,
+		Path:   filepath.Join(t.TempDir(), "test.sqlite"),
+	})
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	defer store.Close()
+
+	migrator, err := migrations.New(store.DB, store.Driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := store.Queries.CreateUser(ctx, db.CreateUserParams{Username: "alice", PasswordHash: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failed := errors.New("failed")
+	err = store.InTx(ctx, func(queries db.Querier) error {
+		if err := queries.DeleteUser(ctx, user.ID); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("got error %v, want %v", err, failed)
+	}
+	if _, err := store.Queries.GetUserByUsername(ctx, "alice"); err != nil {
+		t.Fatalf("user deleted by a rolled back transaction: %v", err)
+	}
+
+	err = store.InTx(ctx, func(queries db.Querier) error {
+		return queries.DeleteUser(ctx, user.ID)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Queries.GetUserByUsername(ctx, "alice"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("got error %v after committing the delete, want no rows", err)
+	}
+}
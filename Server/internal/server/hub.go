@@ -2,46 +2,44 @@ package server
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
 	"log"
-	"math/rand/v2"
 	"net/http"
+	"server/internal/server/config"
 	"server/internal/server/db"
+	"server/internal/server/db/migrations"
 	"server/internal/server/objects"
 	"server/pkg/packets"
+	"sync"
 	"time"
-
-	_ "embed"
-
-	_ "modernc.org/sqlite"
 )
 
-// 最大孢子的数量
-const MaxSpores int = 1000
-
-// Embed the database schema to be used when creating the database tables
-//
-//go:embed db/config/schema.sql
-var schemaGenSql string
-
 // A structure for database transaction context
 type DbTx struct {
 	Ctx     context.Context
-	Queries *db.Queries
+	Queries db.Querier
+
+	store *db.Store
 }
 
 func (h *Hub) NewDbTx() *DbTx {
 	return &DbTx{
 		Ctx:     context.Background(),
-		Queries: db.New(h.dbPool),
+		Queries: h.store.Queries,
+		store:   h.store,
 	}
 }
 
+// 几条要么都成功要么都不做的修改放在一个事务里，fn 里用传进来的 queries
+func (t *DbTx) InTx(fn func(queries db.Querier) error) error {
+	return t.store.InTx(t.Ctx, fn)
+}
+
 type SharedGameObjects struct {
 	//这个ID 是client id 连接ID
-	Players *objects.SharedCollection[*objects.Player]
+	Players *objects.SpatialCollection[*objects.Player]
 	//这个是孢子池
-	Spores *objects.SharedCollection[*objects.Spore]
+	Spores *objects.SpatialCollection[*objects.Spore]
 }
 
 // 客户端状态机句柄
@@ -88,9 +86,49 @@ type ClientInterfacer interface {
 
 	Close(reson string)
 
+	// 客户端所在房间的游戏对象，还没有进入房间时为 nil
 	SharedGameObjects() *SharedGameObjects
 
+	// 所有的房间
+	Rooms() *RoomManager
+
+	// 匹配队列
+	Matchmaker() *Matchmaker
+
+	// 大厅里的客户端
+	Lobby() *Lobby
+
+	// 观战的客户端
+	Spectators() *Spectators
+
+	// 玩家这一局的最大半径，由 Hub 记录
+	PeakRadii() *PeakRadii
+
+	// 服务器设置
+	Config() *config.Config
+
+	// 登录会话，用来断线重连
+	Sessions() *SessionStore
+
+	// 登录和注册的频率限制
+	RateLimiter() *RateLimiter
+
+	// 客户端的 IP，进程内的客户端（机器人、测试）为空
+	RemoteIP() string
+
+	// 重连时换成旧玩家的 ID
+	Reattach(id uint64)
+
 	Broadcast(message packets.Msg)
+
+	// 把玩家输入交给 Hub，在下一次 tick 时处理
+	QueueInput(message packets.Msg)
+
+	// 握手时确定的协议版本和协商好的可选功能，没有握手时版本是 0。
+	// ProtocolVersion 和 HasFeature 在 Hub 的协程里也会调用
+	SetProtocol(version uint32, features []string)
+	ProtocolVersion() uint32
+	HasFeature(feature string) bool
 }
 
 type Hub struct {
@@ -105,11 +143,48 @@ type Hub struct {
 	//反注册渠道
 	UnregisterChan chan ClientInterfacer
 
-	// Database connection pool db 连接池
-	dbPool *sql.DB
+	// 玩家输入渠道，由模拟循环统一处理
+	InputChan chan *PlayerInput
 
-	//游戏池的对象
-	SharedGameObjects *SharedGameObjects
+	// 等待下一次 tick 处理的输入，只在 Run 的协程里访问
+	pendingInputs []*PlayerInput
+
+	// 每个客户端的视野，只在 Run 的协程里访问
+	interests map[uint64]*interestSet
+
+	// 数据库连接池和对应的查询，SQLite 或 PostgreSQL
+	store *db.Store
+
+	// 竞技场，每个房间有自己的游戏对象
+	Rooms *RoomManager
+
+	// 登录之后排队，按分数分组放进房间
+	Matchmaker *Matchmaker
+
+	// 登录之后、开始游戏之前的客户端
+	Lobby *Lobby
+
+	// 观战的客户端，它们没有自己的玩家
+	Spectators *Spectators
+
+	// 玩家这一局的最大半径，状态从这里取统计
+	PeakRadii *PeakRadii
+
+	// 服务器设置
+	Config *config.Config
+
+	// 登录会话
+	Sessions *SessionStore
+
+	// 登录和注册失败的记录
+	RateLimiter *RateLimiter
+
+	// 关闭服务器时通知 Run 退出
+	stopChan chan struct{}
+	stopped  chan struct{}
+
+	// 还在写数据的客户端，关闭时等待它们发完
+	writers sync.WaitGroup
 }
 
 // NewHub returns a new Hub
@@ -121,54 +196,87 @@ type Hub struct {
 // - BroadcastChan is a channel for broadcast the message to all the clients.
 // - RegisterChan is a channel for register the client to the hub.
 // - UnregisterChan is a channel for unregister the client from the hub.
+// - InputChan is a channel for player input, applied on the next simulation tick.
 //
 // The Hub has a map Client, it's used to store all the clients, the key is the
 // client's id, the value is the client's interface.
 //
 // The Hub is run in a goroutine, it will start a loop to listen the channels and
-// broadcast the message to all the clients.
+// broadcast the message to all the clients. The same loop owns the world
+// simulation, so players are only ever moved on the fixed tick.
 //
 // The Hub is the heart of the server, it's responsible for manage all the
 // clients and broadcast the message to all the clients.
-func NewHub() *Hub {
+func NewHub(cfg *config.Config) *Hub {
 
 	//定义数据库池
-	dbPool, err := sql.Open("sqlite", "db.sqlite")
+	store, err := db.Open(cfg.Database)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	rateLimiter := NewRateLimiter(cfg.RateLimit)
+	if cfg.RateLimit.Path != "" {
+		if err := rateLimiter.Load(cfg.RateLimit.Path); err != nil {
+			log.Printf("Failed to load rate limits: %v", err)
+		}
+	}
+
 	return &Hub{
 		Clients:        objects.NewSharedCollection[ClientInterfacer](), //make(map[uint64]ClientInterfacer),
 		BroadcastChan:  make(chan *packets.Packet),
 		RegisterChan:   make(chan ClientInterfacer),
 		UnregisterChan: make(chan ClientInterfacer),
-		dbPool:         dbPool,
-		SharedGameObjects: &SharedGameObjects{
-			Players: objects.NewSharedCollection[*objects.Player](),
-			Spores:  objects.NewSharedCollection[*objects.Spore](), //生成一个孢子池的对象
-		},
+		InputChan:      make(chan *PlayerInput, cfg.Network.SendQueueSize),
+		interests:      make(map[uint64]*interestSet),
+		store:          store,
+		Sessions:       NewSessionStore(cfg.Network.SessionGracePeriod.Duration()),
+		RateLimiter:    rateLimiter,
+		Config:         cfg,
+		stopChan:       make(chan struct{}),
+		stopped:        make(chan struct{}),
+		Rooms:          NewRoomManager(cfg),
+		Matchmaker:     NewMatchmaker(cfg.Matchmaking),
+		Lobby:          NewLobby(),
+		Spectators:     NewSpectators(),
+		PeakRadii:      NewPeakRadii(),
 	}
 }
 
 func (h *Hub) Run() {
 	log.Println("Awaiting for connections...")
 
-	//初始化数据库
+	//初始化数据库，执行还没有执行过的迁移
 	log.Println("Initializing database...")
-	if _, err := h.dbPool.ExecContext(context.Background(), schemaGenSql); err != nil {
+	migrator, err := migrations.New(h.store.DB, h.store.Driver)
+	if err != nil {
+		log.Fatal(err)
+	}
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
 		log.Fatal(err)
 	}
+	for _, migration := range applied {
+		log.Printf("Applied migration %d_%s", migration.Version, migration.Name)
+	}
 
-	// 测试用 生成不同的孢子
-	log.Println("Placing spores")
+	//指定速率补充每个房间的孢子
+	go h.replenishSporesLoop(h.Config.World.ReplenishInterval.Duration())
 
-	for i := 0; i < MaxSpores; i++ {
-		h.SharedGameObjects.Spores.Add(h.NewSpore())
-	}
+	defer close(h.stopped)
+
+	//世界模拟的固定节奏
+	tickInterval := h.Config.World.TickInterval.Duration()
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	// 实时排行榜
+	leaderboardTicker := time.NewTicker(h.Config.World.LeaderboardInterval.Duration())
+	defer leaderboardTicker.Stop()
 
-	//指定速率补充孢子
-	go h.replenishSporesLoop(2 * time.Second)
+	// 匹配队列
+	matchTicker := time.NewTicker(h.Config.Matchmaking.Interval.Duration())
+	defer matchTicker.Stop()
 
 	//等待客户端连接
 	log.Println("Awaiting client registraions")
@@ -180,20 +288,104 @@ func (h *Hub) Run() {
 			client.Initialize(h.Clients.Add(client))
 		case client := <-h.UnregisterChan:
 			// h.Clients[client.Id()] = nil
-			h.Clients.Remove(client.Id())
+			// 重连的客户端可能已经用上了这个 ID
+			if current, exists := h.Clients.Get(client.Id()); exists && current == client {
+				h.Clients.Remove(client.Id())
+			}
+			delete(h.interests, client.Id())
+			h.Sessions.Detach(client.Id())
 		case packet := <-h.BroadcastChan:
-			// for id, client := range h.Clients {
-			// 	if id != packet.SenderId {
-			// 		client.ProcessMessage(packet.SenderId, packet.Msg)
-			// 	}
-			// }
-			h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
-				if clientId != packet.SenderId {
-					client.ProcessMessage(packet.SenderId, packet.Msg)
-				}
-			})
+			h.broadcast(packet)
+		case input := <-h.InputChan:
+			h.pendingInputs = append(h.pendingInputs, input)
+		case <-ticker.C:
+			h.tick(tickInterval.Seconds())
+		case <-leaderboardTicker.C:
+			h.sendLiveLeaderboard()
+		case <-matchTicker.C:
+			h.matchPlayers()
+		case <-h.stopChan:
+			log.Println("Hub stopped")
+			return
+		}
+	}
+}
+
+// Shutdown notifies every client with the given reason, closes their connections after their
+// pending packets are written, then stops the hub and closes the database. It gives up waiting
+// for clients when ctx is done.
+//
+// 客户端关闭时会先执行当前状态的 OnExit，玩家和会话状态在那里保存
+func (h *Hub) Shutdown(ctx context.Context, reason string) error {
+	log.Printf("Shutting down hub: %s", reason)
+
+	h.Clients.ForEach(func(_ uint64, client ClientInterfacer) {
+		client.SocketSend(packets.NewShutdown(reason))
+		client.Close("server shutting down")
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		h.writers.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+		log.Println("All clients disconnected")
+	case <-ctx.Done():
+		err = fmt.Errorf("clients did not disconnect in time: %w", ctx.Err())
+	}
+
+	close(h.stopChan)
+	select {
+	case <-h.stopped:
+	case <-ctx.Done():
+	}
+
+	if h.Config.RateLimit.Path != "" {
+		if saveErr := h.RateLimiter.Save(h.Config.RateLimit.Path); saveErr != nil {
+			log.Printf("Failed to save rate limits: %v", saveErr)
 		}
 	}
+
+	if dbErr := h.store.Close(); dbErr != nil && err == nil {
+		err = dbErr
+	}
+
+	return err
+}
+
+// 把消息发给和发送者在同一个房间（包括观战的）或者都在大厅里的其他客户端
+func (h *Hub) broadcast(packet *packets.Packet) {
+	// for id, client := range h.Clients {
+	// 	if id != packet.SenderId {
+	// 		client.ProcessMessage(packet.SenderId, packet.Msg)
+	// 	}
+	// }
+	if h.Lobby.Has(packet.SenderId) {
+		for _, clientId := range h.Lobby.Members() {
+			if client, exists := h.Clients.Get(clientId); exists && clientId != packet.SenderId {
+				client.ProcessMessage(packet.SenderId, packet.Msg)
+			}
+		}
+		return
+	}
+
+	room, exists := h.watchedRoom(packet.SenderId)
+	if !exists {
+		return
+	}
+
+	h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
+		if clientId == packet.SenderId {
+			return
+		}
+		if other, exists := h.watchedRoom(clientId); exists && other == room {
+			client.ProcessMessage(packet.SenderId, packet.Msg)
+		}
+	})
 }
 
 func (h *Hub) Server(getNewClient func(*Hub, http.ResponseWriter, *http.Request) (ClientInterfacer, error), writer http.ResponseWriter, request *http.Request) {
@@ -201,20 +393,23 @@ func (h *Hub) Server(getNewClient func(*Hub, http.ResponseWriter, *http.Request)
 	client, err := getNewClient(h, writer, request)
 	if err != nil {
 		log.Printf("Error obtaining client:%v", err)
+		return
 	}
 
+	h.Serve(client)
+}
+
+// Serve registers a client that already has a connection and starts its pumps. Every
+// transport (WebSocket, TCP, UDP) goes through here once its connection is set up.
+func (h *Hub) Serve(client ClientInterfacer) {
 	h.RegisterChan <- client
 
-	go client.WritePump()
+	h.writers.Add(1)
+	go func() {
+		defer h.writers.Done()
+		client.WritePump()
+	}()
 	go client.ReadPump()
-
-}
-
-// 新建一个孢子
-func (h *Hub) NewSpore() *objects.Spore {
-	sporeRadius := max(rand.NormFloat64()*3+10, 5)
-	x, y := objects.SpawnCoords(sporeRadius, h.SharedGameObjects.Players, h.SharedGameObjects.Spores) //将玩家传进去，这样就
-	return &objects.Spore{X: x, Y: y, Radius: sporeRadius}
 }
 
 func (h *Hub) replenishSporesLoop(rate time.Duration) {
@@ -222,27 +417,25 @@ func (h *Hub) replenishSporesLoop(rate time.Duration) {
 	defer ticker.Stop()
 
 	for range ticker.C {
-		sporesRemaining := h.SharedGameObjects.Spores.Len()
-		diff := MaxSpores - sporesRemaining
-
-		if diff <= 0 {
-			continue
+		for _, room := range h.Rooms.Rooms() {
+			h.replenishSpores(room)
 		}
+	}
+}
 
-		log.Printf("%d spores remain - going to replenish %d spores", sporesRemaining, diff)
+func (h *Hub) replenishSpores(room *Room) {
+	sporesRemaining := room.SharedGameObjects.Spores.Len()
+	diff := h.Config.World.MaxSpores - sporesRemaining
 
-		// Don't really want to spawn too many at a time, otherwise it can cause a lag spike
-		for i := 0; i < min(diff, 10); i++ {
-			spore := h.NewSpore()
-			sporeId := h.SharedGameObjects.Spores.Add(spore)
+	if diff <= 0 {
+		return
+	}
 
-			h.BroadcastChan <- &packets.Packet{
-				SenderId: 0,
-				Msg:      packets.NewSpore(sporeId, spore),
-			}
+	log.Printf("Room %d: %d spores remain - going to replenish %d spores", room.Id, sporesRemaining, diff)
 
-			// Sleep a bit to avoid lag spikes
-			time.Sleep(50 * time.Millisecond)
-		}
+	// Don't really want to spawn too many at a time, otherwise it can cause a lag spike
+	// New spores reach the clients that can see them on the next tick
+	for i := 0; i < min(diff, h.Config.World.ReplenishWaveSize); i++ {
+		room.SharedGameObjects.Spores.Add(room.NewSpore(h.Config.World.SpawnBound))
 	}
 }
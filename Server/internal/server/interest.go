@@ -0,0 +1,214 @@
+package server
+
+import (
+	"server/internal/server/objects"
+	"server/pkg/packets"
+)
+
+// 每个客户端当前能看到的对象，只在 Run 的协程里访问
+type interestSet struct {
+	// 收到这些对象的连接。重连的客户端用的是旧玩家的 ID，但它没有收到过旧连接的视野和快照
+	client ClientInterfacer
+	// 这些对象所在的房间，孢子的 ID 只在房间里唯一
+	roomId    uint64
+	players   map[uint64]struct{}
+	spores    map[uint64]struct{}
+	snapshots *snapshotHistory
+}
+
+func newInterestSet(client ClientInterfacer, roomId uint64) *interestSet {
+	return &interestSet{
+		client:    client,
+		roomId:    roomId,
+		players:   make(map[uint64]struct{}),
+		spores:    make(map[uint64]struct{}),
+		snapshots: &snapshotHistory{deltas: client.HasFeature(packets.FeatureSnapshotDelta)},
+	}
+}
+
+// 根据玩家大小计算视野半径，玩家越大看得越远
+func (h *Hub) viewRadius(playerRadius float64) float64 {
+	return h.Config.World.ViewRadius + playerRadius*h.Config.World.ViewRadiusScale
+}
+
+// 给每个游戏中和观战的客户端发送视野内的更新，以及进入和离开视野的对象
+func (h *Hub) updateInterests() {
+	h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
+		room, x, y, radius, exists := h.viewOf(clientId)
+		if !exists {
+			// 不在游戏中，下次进入游戏时重新发送完整的视野和快照
+			delete(h.interests, clientId)
+			return
+		}
+
+		interest, exists := h.interests[clientId]
+		if exists && interest.client != client {
+			// 重连之后换了连接，从完整的视野和快照重新开始
+			exists = false
+		}
+		if exists && interest.roomId != room.Id {
+			// 换了房间，旧房间里看到的东西全部离开视野
+			leftPlayers := missingIds(interest.players, nil)
+			leftSpores := missingIds(interest.spores, nil)
+			if client.HasFeature(packets.FeatureOutOfView) && (len(leftPlayers) > 0 || len(leftSpores) > 0) {
+				client.ProcessMessage(0, packets.NewOutOfView(leftPlayers, leftSpores))
+			}
+			exists = false
+		}
+		if !exists {
+			interest = newInterestSet(client, room.Id)
+			h.interests[clientId] = interest
+		}
+
+		h.updateInterest(client, room, x, y, radius, interest)
+	})
+}
+
+// 客户端看的房间和视野的中心、半径。游戏中以自己的玩家为中心；观战时以跟随的玩家为中心，
+// 没有跟随或者跟随的玩家不在了就换成房间里最大的玩家，房间里没有人时看房间中心
+func (h *Hub) viewOf(clientId uint64) (*Room, float64, float64, float64, bool) {
+	if room, exists := h.Rooms.RoomOf(clientId); exists {
+		player, exists := room.SharedGameObjects.Players.Get(clientId)
+		if !exists {
+			return nil, 0, 0, 0, false
+		}
+		return room, player.X, player.Y, h.viewRadius(player.Radius), true
+	}
+
+	spectator, exists := h.Spectators.Get(clientId)
+	if !exists {
+		return nil, 0, 0, 0, false
+	}
+	room, exists := h.Rooms.Get(spectator.RoomId)
+	if !exists {
+		return nil, 0, 0, 0, false
+	}
+
+	var target *objects.Player
+	if spectator.FollowId != 0 {
+		target, _ = room.SharedGameObjects.Players.Get(spectator.FollowId)
+	}
+	if target == nil {
+		target = biggestPlayer(room)
+	}
+	if target == nil {
+		return room, 0, 0, h.viewRadius(0), true
+	}
+	return room, target.X, target.Y, h.viewRadius(target.Radius), true
+}
+
+// 房间里最大的玩家，一样大时取 ID 小的，房间里没有玩家时返回 nil
+func biggestPlayer(room *Room) *objects.Player {
+	var biggest *objects.Player
+	for _, entry := range sortedPlayers(room) {
+		if biggest == nil || entry.player.Radius > biggest.Radius {
+			biggest = entry.player
+		}
+	}
+	return biggest
+}
+
+func (h *Hub) updateInterest(client ClientInterfacer, room *Room, x float64, y float64, radius float64, interest *interestSet) {
+	visiblePlayers := make(map[uint64]struct{}, len(interest.players))
+	var playerMsgs []*packets.PlayerMessage
+	room.SharedGameObjects.Players.ForEachInRadius(x, y, radius, func(playerId uint64, other *objects.Player) {
+		visiblePlayers[playerId] = struct{}{}
+		playerMsgs = append(playerMsgs, packets.NewPlayerMessage(playerId, other))
+	})
+
+	visibleSpores := make(map[uint64]struct{}, len(interest.spores))
+	var enteredSpores []*packets.SporeMessage
+	room.SharedGameObjects.Spores.ForEachInRadius(x, y, radius, func(sporeId uint64, spore *objects.Spore) {
+		visibleSpores[sporeId] = struct{}{}
+		if _, seen := interest.spores[sporeId]; !seen {
+			enteredSpores = append(enteredSpores, packets.NewSporeMessage(sporeId, spore))
+		}
+	})
+
+	leftPlayers := missingIds(interest.players, visiblePlayers)
+	leftSpores := missingIds(interest.spores, visibleSpores)
+
+	interest.players = visiblePlayers
+	interest.spores = visibleSpores
+
+	// 没有协商 out_of_view 的客户端从完整快照里自己判断哪些玩家离开了视野
+	if client.HasFeature(packets.FeatureOutOfView) && (len(leftPlayers) > 0 || len(leftSpores) > 0) {
+		client.ProcessMessage(0, packets.NewOutOfView(leftPlayers, leftSpores))
+	}
+
+	// 刚进入游戏时的整个视野和补充的孢子都按块批量发送，不支持批量的客户端一个一个发
+	if client.HasFeature(packets.FeatureSporesBatch) {
+		for _, batch := range packets.NewSporesBatches(enteredSpores, h.Config.Network.MaxFrameSize) {
+			client.ProcessMessage(0, batch)
+		}
+	} else {
+		for _, spore := range enteredSpores {
+			client.ProcessMessage(0, &packets.Packet_Spore{Spore: spore})
+		}
+	}
+
+	// 旧客户端不认识快照，每个玩家单独发一个 player 消息
+	if client.ProtocolVersion() == packets.LegacyProtocolVersion {
+		for _, player := range playerMsgs {
+			client.ProcessMessage(0, &packets.Packet_Player{Player: player})
+		}
+		return
+	}
+
+	// 新进入视野的玩家在快照里带上完整信息，其余的只发送变化的字段
+	client.ProcessMessage(0, interest.snapshots.next(playerMsgs))
+}
+
+// 只发给同一个房间里能看到孢子的客户端，吃掉孢子的玩家自己一定会收到（用来统计）
+func (h *Hub) sendSporeConsumed(room *Room, sporeId uint64, playerId uint64) {
+	message := packets.NewSporeConsumed(sporeId, playerId)
+
+	h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
+		interest, exists := h.interests[clientId]
+		exists = exists && interest.roomId == room.Id
+
+		notify := clientId == playerId
+		if exists {
+			_, visible := interest.spores[sporeId]
+			notify = notify || visible
+			delete(interest.spores, sporeId)
+		}
+
+		if notify {
+			client.ProcessMessage(0, message)
+		}
+	})
+}
+
+// 发给同一个房间里能看到任意一方的客户端，被吞并的玩家自己一定会收到
+func (h *Hub) sendPlayerConsumed(room *Room, playerId uint64, consumerId uint64) {
+	message := packets.NewPlayerConsumed(playerId, consumerId)
+
+	h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
+		interest, exists := h.interests[clientId]
+		exists = exists && interest.roomId == room.Id
+
+		notify := clientId == playerId
+		if exists {
+			_, seesPlayer := interest.players[playerId]
+			_, seesConsumer := interest.players[consumerId]
+			notify = notify || seesPlayer || seesConsumer
+			delete(interest.players, playerId)
+		}
+
+		if notify {
+			client.ProcessMessage(0, message)
+		}
+	})
+}
+
+// 在旧集合里但不在新集合里的 ID
+func missingIds(old map[uint64]struct{}, current map[uint64]struct{}) []uint64 {
+	var ids []uint64
+	for id := range old {
+		if _, ok := current[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
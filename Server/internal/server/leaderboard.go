@@ -0,0 +1,58 @@
+package server
+
+import (
+	"slices"
+
+	"server/pkg/packets"
+)
+
+// 每个房间有自己的实时排行榜
+func (h *Hub) sendLiveLeaderboard() {
+	for _, room := range h.Rooms.Rooms() {
+		h.sendRoomLeaderboard(room)
+	}
+}
+
+// 把房间里按质量排名的前几名和每个人自己的名次发给游戏中和观战的客户端，只用服务器自己的半径
+func (h *Hub) sendRoomLeaderboard(room *Room) {
+	players := sortedPlayers(room)
+	if len(players) == 0 {
+		return
+	}
+
+	// 质量一样时按 ID 排，名次不会来回跳
+	slices.SortStableFunc(players, func(a, b playerEntry) int {
+		switch {
+		case a.player.Radius > b.player.Radius:
+			return -1
+		case a.player.Radius < b.player.Radius:
+			return 1
+		}
+		return 0
+	})
+
+	ranks := make(map[uint64]uint32, len(players))
+	for i, entry := range players {
+		ranks[entry.id] = uint32(i + 1)
+	}
+
+	top := players[:min(len(players), h.Config.World.LeaderboardSize)]
+	entries := make([]*packets.LiveLeaderboardEntryMessage, 0, len(top))
+	for _, entry := range top {
+		entries = append(entries, &packets.LiveLeaderboardEntryMessage{
+			PlayerId: entry.id,
+			Name:     entry.player.Name,
+			Mass:     radToMass(entry.player.Radius),
+		})
+	}
+
+	h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
+		// 观战的客户端也收到排行榜，自己没有名次
+		rank, inGame := ranks[clientId]
+		if spectator, watching := h.Spectators.Get(clientId); !inGame && (!watching || spectator.RoomId != room.Id) {
+			return
+		}
+
+		client.ProcessMessage(0, packets.NewLiveLeaderboard(entries, rank, uint32(len(players))))
+	})
+}
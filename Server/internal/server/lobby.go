@@ -0,0 +1,54 @@
+package server
+
+import (
+	"slices"
+	"sync"
+)
+
+// A thread-safe set of the clients in the Lobby state. Chat from a lobby member goes to
+// the other members instead of a room.
+type Lobby struct {
+	members map[uint64]struct{}
+	mux     sync.Mutex
+}
+
+func NewLobby() *Lobby {
+	return &Lobby{
+		members: make(map[uint64]struct{}),
+	}
+}
+
+func (l *Lobby) Join(clientId uint64) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.members[clientId] = struct{}{}
+}
+
+func (l *Lobby) Leave(clientId uint64) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	delete(l.members, clientId)
+}
+
+func (l *Lobby) Has(clientId uint64) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	_, exists := l.members[clientId]
+	return exists
+}
+
+// 按 ID 排序的大厅里的客户端
+func (l *Lobby) Members() []uint64 {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	ids := make([]uint64, 0, len(l.members))
+	for id := range l.members {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
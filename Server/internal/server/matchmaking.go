@@ -0,0 +1,316 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"server/internal/server/config"
+	"server/pkg/packets"
+)
+
+// 队伍里排队的人已经有 target_size 个，再多就永远凑不成一组
+var ErrPartyFull = errors.New("party is full")
+
+// 一个排队的玩家
+type MatchTicket struct {
+	PlayerId uint64
+	Rating   int64
+	// 同一个队伍的玩家一起匹配，空表示一个人
+	Party string
+	// 只和同一个地区（或者没有指定地区）的玩家匹配
+	Region   string
+	QueuedAt time.Time
+
+	// 已经分好组、Hub 还在找房间。还算在队伍的人数里，但不参加匹配，也不在队列状态里
+	claimed bool
+}
+
+// 发给排队的客户端的状态
+type QueueStatus struct {
+	// 按排队时间的名次，从 1 开始
+	Position     int
+	QueueSize    int
+	Waited       time.Duration
+	Rating       int64
+	RatingSpread int
+	TargetSize   int
+}
+
+// 一起匹配的玩家：一个队伍或者一个人
+type matchUnit struct {
+	tickets  []*MatchTicket
+	rating   int64
+	region   string
+	queuedAt time.Time
+}
+
+// A thread-safe matchmaking queue. Clients in the Matchmaking state add and remove their
+// tickets; the hub periodically asks for groups of players with close ratings and hands
+// each group to a room.
+type Matchmaker struct {
+	config  config.MatchmakingConfig
+	tickets map[uint64]*MatchTicket
+	mux     sync.Mutex
+}
+
+func NewMatchmaker(cfg config.MatchmakingConfig) *Matchmaker {
+	return &Matchmaker{
+		config:  cfg,
+		tickets: make(map[uint64]*MatchTicket),
+	}
+}
+
+// Enqueue adds the ticket to the queue. Enqueueing a player that is already queued only
+// updates its hints and keeps its place. A party can have at most target_size players in the
+// queue; joining a full party fails with ErrPartyFull and leaves the queue unchanged.
+func (m *Matchmaker) Enqueue(ticket MatchTicket) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if ticket.Party != "" {
+		members := 0
+		for id, other := range m.tickets {
+			if id != ticket.PlayerId && other.Party == ticket.Party {
+				members++
+			}
+		}
+		if members >= m.config.TargetSize {
+			return ErrPartyFull
+		}
+	}
+
+	if old, exists := m.tickets[ticket.PlayerId]; exists {
+		ticket.QueuedAt = old.QueuedAt
+		ticket.claimed = old.claimed
+	}
+	m.tickets[ticket.PlayerId] = &ticket
+	return nil
+}
+
+func (m *Matchmaker) Remove(playerIds ...uint64) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, playerId := range playerIds {
+		delete(m.tickets, playerId)
+	}
+}
+
+// Release puts claimed players back into the queue when the hub could not find a room for
+// their group. They keep their places.
+func (m *Matchmaker) Release(playerIds ...uint64) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, playerId := range playerIds {
+		if ticket, exists := m.tickets[playerId]; exists {
+			ticket.claimed = false
+		}
+	}
+}
+
+// 等得越久允许的分数差越大
+func (m *Matchmaker) spread(queuedAt time.Time, now time.Time) int {
+	waited := int(now.Sub(queuedAt).Seconds())
+	return min(m.config.RatingSpread+waited*m.config.RatingSpreadPerSecond, m.config.MaxRatingSpread)
+}
+
+// 还在排队（没有分好组）的玩家，调用方需要持有锁
+func (m *Matchmaker) sortedTicketsLocked() []*MatchTicket {
+	tickets := make([]*MatchTicket, 0, len(m.tickets))
+	for _, ticket := range m.tickets {
+		if !ticket.claimed {
+			tickets = append(tickets, ticket)
+		}
+	}
+	slices.SortFunc(tickets, func(a, b *MatchTicket) int {
+		if c := a.QueuedAt.Compare(b.QueuedAt); c != 0 {
+			return c
+		}
+		return int(a.PlayerId) - int(b.PlayerId)
+	})
+	return tickets
+}
+
+// 所有排队的玩家的状态
+func (m *Matchmaker) Statuses(now time.Time) map[uint64]QueueStatus {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	tickets := m.sortedTicketsLocked()
+	statuses := make(map[uint64]QueueStatus, len(tickets))
+	for i, ticket := range tickets {
+		statuses[ticket.PlayerId] = QueueStatus{
+			Position:     i + 1,
+			QueueSize:    len(tickets),
+			Waited:       now.Sub(ticket.QueuedAt),
+			Rating:       ticket.Rating,
+			RatingSpread: m.spread(ticket.QueuedAt, now),
+			TargetSize:   m.config.TargetSize,
+		}
+	}
+	return statuses
+}
+
+// 一个玩家的队列状态，不在队列里时返回 false
+func (m *Matchmaker) Status(playerId uint64, now time.Time) (QueueStatus, bool) {
+	status, exists := m.Statuses(now)[playerId]
+	return status, exists
+}
+
+// 发给客户端的队列状态
+func (s QueueStatus) Msg() packets.Msg {
+	return packets.NewQueueStatus(uint32(s.Position), uint32(s.QueueSize), s.Waited, s.Rating, uint32(s.RatingSpread), uint32(s.TargetSize))
+}
+
+// Claim takes the ready groups out of the queue. A group is ready when it has target_size
+// players whose ratings are within each other's spread, or when its oldest player has
+// waited max_wait. Players in the same party always end up in the same group. The caller
+// must Remove the players once they have a room, or Release them to queue again.
+func (m *Matchmaker) Claim(now time.Time) [][]MatchTicket {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	units := m.unitsLocked()
+	used := make([]bool, len(units))
+
+	var groups [][]MatchTicket
+	for i, anchor := range units {
+		if used[i] {
+			continue
+		}
+
+		members := []int{i}
+		size := len(anchor.tickets)
+		region := anchor.region
+		anchorSpread := m.spread(anchor.queuedAt, now)
+
+		// 分数最接近的先进组
+		var candidates []int
+		for j := i + 1; j < len(units); j++ {
+			other := units[j]
+			diff := abs(other.rating - anchor.rating)
+			if !used[j] && diff <= int64(anchorSpread) && diff <= int64(m.spread(other.queuedAt, now)) {
+				candidates = append(candidates, j)
+			}
+		}
+		slices.SortStableFunc(candidates, func(a, b int) int {
+			return int(abs(units[a].rating-anchor.rating) - abs(units[b].rating-anchor.rating))
+		})
+
+		for _, j := range candidates {
+			other := units[j]
+			if size+len(other.tickets) > m.config.TargetSize {
+				continue
+			}
+			if region != "" && other.region != "" && other.region != region {
+				continue
+			}
+			if region == "" {
+				region = other.region
+			}
+			members = append(members, j)
+			size += len(other.tickets)
+		}
+
+		if size < m.config.TargetSize && now.Sub(anchor.queuedAt) < m.config.MaxWait.Duration() {
+			continue
+		}
+
+		var group []MatchTicket
+		for _, j := range members {
+			used[j] = true
+			for _, ticket := range units[j].tickets {
+				ticket.claimed = true
+				group = append(group, *ticket)
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// 把同一个队伍的玩家合在一起，按排队时间排序。队伍的分数取平均，地区取第一个指定了的
+// 调用方需要持有锁
+func (m *Matchmaker) unitsLocked() []*matchUnit {
+	var units []*matchUnit
+	parties := make(map[string]*matchUnit)
+
+	for _, ticket := range m.sortedTicketsLocked() {
+		unit, exists := parties[ticket.Party]
+		if !exists || ticket.Party == "" {
+			unit = &matchUnit{queuedAt: ticket.QueuedAt}
+			units = append(units, unit)
+			if ticket.Party != "" {
+				parties[ticket.Party] = unit
+			}
+		}
+
+		unit.tickets = append(unit.tickets, ticket)
+		if unit.region == "" {
+			unit.region = ticket.Region
+		}
+	}
+
+	for _, unit := range units {
+		var total int64
+		for _, ticket := range unit.tickets {
+			total += ticket.Rating
+		}
+		unit.rating = total / int64(len(unit.tickets))
+	}
+
+	return units
+}
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// 把准备好的组放进房间，没有房间时放回队列等下一次（找房间时它们还占着队伍的人数）；然后告诉其他排队的客户端现在的状态
+func (h *Hub) matchPlayers() {
+	now := time.Now()
+
+	for _, group := range h.Matchmaker.Claim(now) {
+		ids := make([]uint64, 0, len(group))
+		for _, ticket := range group {
+			ids = append(ids, ticket.PlayerId)
+		}
+
+		room, err := h.Rooms.JoinGroup(ids)
+		if err != nil {
+			log.Printf("No room for matched players %v: %v", ids, err)
+			h.Matchmaker.Release(ids...)
+			continue
+		}
+		h.Matchmaker.Remove(ids...)
+
+		log.Printf("Matched players %v into room %d", ids, room.Id)
+		joined := packets.NewRoomJoined(&packets.RoomInfoMessage{
+			Id:       room.Id,
+			Players:  uint32(h.Rooms.Info(room).Players),
+			Capacity: uint32(room.Capacity),
+		})
+		for _, id := range ids {
+			if client, exists := h.Clients.Get(id); exists {
+				client.ProcessMessage(0, joined)
+			} else {
+				// 排队时断开了，重连的时候会重新找房间
+				h.Rooms.Leave(id)
+			}
+		}
+	}
+
+	for id, status := range h.Matchmaker.Statuses(now) {
+		if client, exists := h.Clients.Get(id); exists {
+			client.ProcessMessage(0, status.Msg())
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"server/internal/server/config"
+)
+
+func newTestMatchmaker() *Matchmaker {
+	return NewMatchmaker(config.MatchmakingConfig{
+		TargetSize:            2,
+		RatingSpread:          100,
+		RatingSpreadPerSecond: 25,
+		MaxRatingSpread:       500,
+		MaxWait:               config.Duration(30 * time.Second),
+	})
+}
+
+// 每组里按 ID 排序的玩家
+func groupIds(groups [][]MatchTicket) [][]uint64 {
+	var ids [][]uint64
+	for _, group := range groups {
+		var groupIds []uint64
+		for _, ticket := range group {
+			groupIds = append(groupIds, ticket.PlayerId)
+		}
+		slices.Sort(groupIds)
+		ids = append(ids, groupIds)
+	}
+	return ids
+}
+
+func TestMatchmakerClaim(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+
+	tests := []struct {
+		name       string
+		tickets    []MatchTicket
+		wantGroups [][]uint64
+	}{
+		{
+			name: "close ratings",
+			tickets: []MatchTicket{
+				{PlayerId: 1, Rating: 1000, QueuedAt: now},
+				{PlayerId: 2, Rating: 1050, QueuedAt: now},
+			},
+			wantGroups: [][]uint64{{1, 2}},
+		},
+		{
+			name: "ratings too far apart",
+			tickets: []MatchTicket{
+				{PlayerId: 1, Rating: 1000, QueuedAt: now},
+				{PlayerId: 2, Rating: 2000, QueuedAt: now},
+			},
+		},
+		{
+			name: "closest rating first",
+			tickets: []MatchTicket{
+				{PlayerId: 1, Rating: 1000, QueuedAt: ago(time.Second)},
+				{PlayerId: 2, Rating: 1090, QueuedAt: now},
+				{PlayerId: 3, Rating: 1010, QueuedAt: now},
+			},
+			wantGroups: [][]uint64{{1, 3}},
+		},
+		{
+			name: "spread widens while waiting",
+			tickets: []MatchTicket{
+				{PlayerId: 1, Rating: 1000, QueuedAt: ago(10 * time.Second)},
+				{PlayerId: 2, Rating: 1300, QueuedAt: ago(10 * time.Second)},
+			},
+			wantGroups: [][]uint64{{1, 2}},
+		},
+		{
+			name: "both players must accept the difference",
+			tickets: []MatchTicket{
+				{PlayerId: 1, Rating: 1000, QueuedAt: ago(10 * time.Second)},
+				{PlayerId: 2, Rating: 1300, QueuedAt: now},
+			},
+		},
+		{
+			name: "max wait",
+			tickets: []MatchTicket{
+				{PlayerId: 1, Rating: 1000, QueuedAt: ago(time.Minute)},
+				{PlayerId: 2, Rating: 3000, QueuedAt: now},
+			},
+			wantGroups: [][]uint64{{1}},
+		},
+		{
+			name: "party stays together",
+			tickets: []MatchTicket{
+				{PlayerId: 1, Rating: 1000, QueuedAt: ago(time.Second)},
+				{PlayerId: 2, Rating: 1000, Party: "p", QueuedAt: now},
+				{PlayerId: 3, Rating: 1000, Party: "p", QueuedAt: now},
+			},
+			wantGroups: [][]uint64{{2, 3}},
+		},
+		{
+			name: "regions",
+			tickets: []MatchTicket{
+				{PlayerId: 1, Rating: 1000, Region: "eu", QueuedAt: ago(time.Second)},
+				{PlayerId: 2, Rating: 1000, Region: "us", QueuedAt: now},
+				{PlayerId: 3, Rating: 1000, QueuedAt: now},
+			},
+			wantGroups: [][]uint64{{1, 3}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestMatchmaker()
+			for _, ticket := range test.tickets {
+				m.Enqueue(ticket)
+			}
+
+			got := groupIds(m.Claim(now))
+			if !slices.EqualFunc(got, test.wantGroups, slices.Equal) {
+				t.Fatalf("got groups %v, want %v", got, test.wantGroups)
+			}
+
+			// 分好组的玩家离开队列，剩下的继续排队
+			statuses := m.Statuses(now)
+			for _, group := range got {
+				for _, id := range group {
+					if _, queued := statuses[id]; queued {
+						t.Errorf("matched player %d is still queued", id)
+					}
+				}
+			}
+			if want := len(test.tickets) - len(slices.Concat(got...)); len(statuses) != want {
+				t.Errorf("got %d players left in the queue, want %d", len(statuses), want)
+			}
+		})
+	}
+}
+
+func TestMatchmakerStatus(t *testing.T) {
+	m := newTestMatchmaker()
+	now := time.Now()
+
+	m.Enqueue(MatchTicket{PlayerId: 1, Rating: 1000, QueuedAt: now.Add(-4 * time.Second)})
+	m.Enqueue(MatchTicket{PlayerId: 2, Rating: 5000, QueuedAt: now.Add(-2 * time.Second)})
+
+	// 更新队伍和地区时保留排队的位置
+	m.Enqueue(MatchTicket{PlayerId: 1, Rating: 1000, Region: "eu", QueuedAt: now})
+
+	status, queued := m.Status(1, now)
+	if !queued {
+		t.Fatal("player 1 is not queued")
+	}
+	want := QueueStatus{Position: 1, QueueSize: 2, Waited: 4 * time.Second, Rating: 1000, RatingSpread: 200, TargetSize: 2}
+	if status != want {
+		t.Errorf("got status %+v, want %+v", status, want)
+	}
+
+	m.Remove(1)
+	if status, _ := m.Status(2, now); status.Position != 1 || status.QueueSize != 1 {
+		t.Errorf("got status %+v after the first player left", status)
+	}
+}
+
+func TestMatchmakerPartySize(t *testing.T) {
+	m := newTestMatchmaker()
+	now := time.Now()
+
+	for _, id := range []uint64{1, 2} {
+		if err := m.Enqueue(MatchTicket{PlayerId: id, Rating: 1000, Party: "a", QueuedAt: now}); err != nil {
+			t.Fatalf("could not queue player %d: %v", id, err)
+		}
+	}
+	third := MatchTicket{PlayerId: 3, Rating: 1000, Party: "a", QueuedAt: now}
+	if err := m.Enqueue(third); err != ErrPartyFull {
+		t.Errorf("got %v queueing a third player, want ErrPartyFull", err)
+	}
+	// 已经在队伍里的玩家可以更新自己的票
+	if err := m.Enqueue(MatchTicket{PlayerId: 1, Rating: 1000, Party: "a", Region: "eu", QueuedAt: now}); err != nil {
+		t.Errorf("could not update player 1: %v", err)
+	}
+
+	// Hub 找房间的时候，分好组的队伍还占着人数
+	if got := groupIds(m.Claim(now)); !slices.EqualFunc(got, [][]uint64{{1, 2}}, slices.Equal) {
+		t.Fatalf("got groups %v", got)
+	}
+	if err := m.Enqueue(third); err != ErrPartyFull {
+		t.Errorf("got %v queueing a third player while the party is claimed, want ErrPartyFull", err)
+	}
+
+	// 没有房间，放回队列之后下一次还能分成同一组
+	m.Release(1, 2)
+	if got := groupIds(m.Claim(now)); !slices.EqualFunc(got, [][]uint64{{1, 2}}, slices.Equal) {
+		t.Fatalf("got groups %v after releasing the party", got)
+	}
+
+	m.Remove(1, 2)
+	if err := m.Enqueue(third); err != nil {
+		t.Errorf("could not queue player 3 after the party left: %v", err)
+	}
+}
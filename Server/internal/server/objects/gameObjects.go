@@ -9,6 +9,7 @@ type Player struct {
 	Radius    float64 //范围
 	Direction float64 //方向
 	Speed     float64 //速度
+	Color     uint32  //颜色 0xRRGGBB，0 表示由客户端决定
 }
 
 // 构成孢子的基本要素
@@ -17,3 +18,13 @@ type Spore struct {
 	Y      float64
 	Radius float64
 }
+
+// 玩家在空间索引里的范围
+func (p *Player) Bounds() (float64, float64, float64) {
+	return p.X, p.Y, p.Radius
+}
+
+// 孢子在空间索引里的范围
+func (s *Spore) Bounds() (float64, float64, float64) {
+	return s.X, s.Y, s.Radius
+}
@@ -79,8 +79,11 @@ func (s *SharedCollection[T]) Get(id uint64) (T, bool) {
 	return obj, found
 }
 
-// 获得地图的长度(不带锁并不精确)
+// 获得地图的长度
 func (s *SharedCollection[T]) Len() int {
+	s.mapMux.Lock()
+	defer s.mapMux.Unlock()
+
 	return len(s.objectsMap)
 }
 
@@ -0,0 +1,72 @@
+package objects
+
+// 默认的网格大小
+const DefaultCellSize float64 = 100
+
+// 可以放进空间索引的对象
+type Bounded interface {
+	Bounds() (x, y, radius float64)
+}
+
+// A SharedCollection that keeps a SpatialGrid in sync with its contents, so objects
+// can be looked up by position. 对象移动或者变大之后需要调用 Update
+type SpatialCollection[T Bounded] struct {
+	*SharedCollection[T]
+	index *SpatialGrid
+}
+
+func NewSpatialCollection[T Bounded](cellSize float64, capacity ...int) *SpatialCollection[T] {
+	return &SpatialCollection[T]{
+		SharedCollection: NewSharedCollection[T](capacity...),
+		index:            NewSpatialGrid(cellSize),
+	}
+}
+
+// Add an object to the collection and the spatial index, returns the ID of the object added.
+// The bounds are read before the object becomes visible to other goroutines.
+func (s *SpatialCollection[T]) Add(obj T, id ...uint64) uint64 {
+	x, y, radius := obj.Bounds()
+	thisId := s.SharedCollection.Add(obj, id...)
+	s.index.Update(thisId, x, y, radius)
+
+	return thisId
+}
+
+func (s *SpatialCollection[T]) Remove(id uint64) {
+	s.SharedCollection.Remove(id)
+	s.index.Remove(id)
+}
+
+// 对象的位置或者半径变了之后刷新索引
+func (s *SpatialCollection[T]) Update(id uint64) {
+	obj, exists := s.Get(id)
+	if !exists {
+		return
+	}
+
+	x, y, radius := obj.Bounds()
+	s.index.Update(id, x, y, radius)
+}
+
+// 遍历和给定圆重叠的对象
+func (s *SpatialCollection[T]) ForEachInRadius(x, y, radius float64, callback func(uint64, T)) {
+	s.forEachId(s.index.QueryRadius(x, y, radius), callback)
+}
+
+// 遍历和给定矩形相交的对象
+func (s *SpatialCollection[T]) ForEachInRect(minX, minY, maxX, maxY float64, callback func(uint64, T)) {
+	s.forEachId(s.index.QueryRect(minX, minY, maxX, maxY), callback)
+}
+
+// 判断给定圆里面是否有对象
+func (s *SpatialCollection[T]) AnyInRadius(x, y, radius float64) bool {
+	return len(s.index.QueryRadius(x, y, radius)) > 0
+}
+
+func (s *SpatialCollection[T]) forEachId(ids []uint64, callback func(uint64, T)) {
+	for _, id := range ids {
+		if obj, exists := s.Get(id); exists {
+			callback(id, obj)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package objects
+
+import (
+	"math"
+	"slices"
+	"sync"
+)
+
+// 网格的坐标
+type cellKey struct {
+	x int64
+	y int64
+}
+
+// 网格里记录的对象范围
+type gridEntry struct {
+	x      float64
+	y      float64
+	radius float64
+	minKey cellKey
+	maxKey cellKey
+}
+
+// A thread-safe uniform grid (spatial hash) of circles, used to find objects near a point
+// without walking the whole collection. 每个对象按包围盒放进所有覆盖到的格子里
+type SpatialGrid struct {
+	cellSize float64
+	cells    map[cellKey]map[uint64]struct{}
+	entries  map[uint64]gridEntry
+	gridMux  sync.RWMutex
+}
+
+func NewSpatialGrid(cellSize float64) *SpatialGrid {
+	return &SpatialGrid{
+		cellSize: cellSize,
+		cells:    make(map[cellKey]map[uint64]struct{}),
+		entries:  make(map[uint64]gridEntry),
+	}
+}
+
+// Insert or move the circle with the given ID
+func (g *SpatialGrid) Update(id uint64, x, y, radius float64) {
+	g.gridMux.Lock()
+	defer g.gridMux.Unlock()
+
+	minKey := g.keyOf(x-radius, y-radius)
+	maxKey := g.keyOf(x+radius, y+radius)
+
+	old, exists := g.entries[id]
+	if exists && old.minKey == minKey && old.maxKey == maxKey {
+		// 还在同样的格子里，只更新位置
+		g.entries[id] = gridEntry{x: x, y: y, radius: radius, minKey: minKey, maxKey: maxKey}
+		return
+	}
+
+	if exists {
+		g.removeFromCells(id, old)
+	}
+
+	entry := gridEntry{x: x, y: y, radius: radius, minKey: minKey, maxKey: maxKey}
+	g.entries[id] = entry
+
+	for cx := minKey.x; cx <= maxKey.x; cx++ {
+		for cy := minKey.y; cy <= maxKey.y; cy++ {
+			key := cellKey{cx, cy}
+			cell, ok := g.cells[key]
+			if !ok {
+				cell = make(map[uint64]struct{})
+				g.cells[key] = cell
+			}
+			cell[id] = struct{}{}
+		}
+	}
+}
+
+func (g *SpatialGrid) Remove(id uint64) {
+	g.gridMux.Lock()
+	defer g.gridMux.Unlock()
+
+	entry, exists := g.entries[id]
+	if !exists {
+		return
+	}
+
+	g.removeFromCells(id, entry)
+	delete(g.entries, id)
+}
+
+// 返回和给定圆重叠的所有对象 ID（按 ID 排序）
+func (g *SpatialGrid) QueryRadius(x, y, radius float64) []uint64 {
+	g.gridMux.RLock()
+	defer g.gridMux.RUnlock()
+
+	return g.query(x-radius, y-radius, x+radius, y+radius, func(entry gridEntry) bool {
+		dx := entry.x - x
+		dy := entry.y - y
+		threshold := entry.radius + radius
+		return dx*dx+dy*dy <= threshold*threshold
+	})
+}
+
+// 返回包围盒和给定矩形相交的所有对象 ID（按 ID 排序）
+func (g *SpatialGrid) QueryRect(minX, minY, maxX, maxY float64) []uint64 {
+	g.gridMux.RLock()
+	defer g.gridMux.RUnlock()
+
+	return g.query(minX, minY, maxX, maxY, func(entry gridEntry) bool {
+		return entry.x+entry.radius >= minX && entry.x-entry.radius <= maxX &&
+			entry.y+entry.radius >= minY && entry.y-entry.radius <= maxY
+	})
+}
+
+// 获得网格里对象的数量
+func (g *SpatialGrid) Len() int {
+	g.gridMux.RLock()
+	defer g.gridMux.RUnlock()
+
+	return len(g.entries)
+}
+
+// 调用方需要持有读锁
+func (g *SpatialGrid) query(minX, minY, maxX, maxY float64, match func(gridEntry) bool) []uint64 {
+	minKey := g.keyOf(minX, minY)
+	maxKey := g.keyOf(maxX, maxY)
+
+	seen := make(map[uint64]struct{})
+	var ids []uint64
+
+	for cx := minKey.x; cx <= maxKey.x; cx++ {
+		for cy := minKey.y; cy <= maxKey.y; cy++ {
+			for id := range g.cells[cellKey{cx, cy}] {
+				if _, ok := seen[id]; ok {
+					continue
+				}
+				seen[id] = struct{}{}
+
+				if match(g.entries[id]) {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+
+	slices.Sort(ids)
+	return ids
+}
+
+// 调用方需要持有写锁
+func (g *SpatialGrid) removeFromCells(id uint64, entry gridEntry) {
+	for cx := entry.minKey.x; cx <= entry.maxKey.x; cx++ {
+		for cy := entry.minKey.y; cy <= entry.maxKey.y; cy++ {
+			key := cellKey{cx, cy}
+			cell := g.cells[key]
+			delete(cell, id)
+			if len(cell) == 0 {
+				delete(g.cells, key)
+			}
+		}
+	}
+}
+
+func (g *SpatialGrid) keyOf(x, y float64) cellKey {
+	return cellKey{
+		x: int64(math.Floor(x / g.cellSize)),
+		y: int64(math.Floor(y / g.cellSize)),
+	}
+}
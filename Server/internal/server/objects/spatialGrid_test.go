@@ -0,0 +1,191 @@
+package objects
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type testCircle struct {
+	x, y, radius float64
+}
+
+// 不用网格，逐个检查所有圆
+type bruteForce map[uint64]testCircle
+
+func (b bruteForce) queryRadius(x, y, radius float64) []uint64 {
+	var ids []uint64
+	for id, c := range b {
+		dx, dy, threshold := c.x-x, c.y-y, c.radius+radius
+		if dx*dx+dy*dy <= threshold*threshold {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (b bruteForce) queryRect(minX, minY, maxX, maxY float64) []uint64 {
+	var ids []uint64
+	for id, c := range b {
+		if c.x+c.radius >= minX && c.x-c.radius <= maxX && c.y+c.radius >= minY && c.y-c.radius <= maxY {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// 表格测试里的一步：插入或移动一个圆，remove 时删除它
+type gridOp struct {
+	id     uint64
+	circle testCircle
+	remove bool
+}
+
+func TestSpatialGrid(t *testing.T) {
+	// 格子大小是 10
+	tests := []struct {
+		name  string
+		ops   []gridOp
+		query testCircle
+		want  []uint64
+	}{
+		{
+			name:  "inside one cell",
+			ops:   []gridOp{{id: 1, circle: testCircle{5, 5, 1}}},
+			query: testCircle{5, 5, 1},
+			want:  []uint64{1},
+		},
+		{
+			name:  "touching circles overlap",
+			ops:   []gridOp{{id: 1, circle: testCircle{0, 0, 2}}},
+			query: testCircle{5, 0, 3},
+			want:  []uint64{1},
+		},
+		{
+			name:  "query from the neighbouring cell",
+			ops:   []gridOp{{id: 1, circle: testCircle{9.5, 5, 1}}},
+			query: testCircle{11, 5, 1},
+			want:  []uint64{1},
+		},
+		{
+			name:  "negative coordinates",
+			ops:   []gridOp{{id: 1, circle: testCircle{-0.5, -0.5, 0.2}}, {id: 2, circle: testCircle{0.5, 0.5, 0.2}}},
+			query: testCircle{-1, -1, 0.6},
+			want:  []uint64{1},
+		},
+		{
+			name:  "moved out of the queried cell",
+			ops:   []gridOp{{id: 1, circle: testCircle{5, 5, 1}}, {id: 1, circle: testCircle{25, 5, 1}}},
+			query: testCircle{5, 5, 2},
+			want:  nil,
+		},
+		{
+			name:  "moved into the queried cell",
+			ops:   []gridOp{{id: 1, circle: testCircle{-25, 5, 1}}, {id: 1, circle: testCircle{5, 5, 1}}},
+			query: testCircle{5, 5, 2},
+			want:  []uint64{1},
+		},
+		{
+			name:  "grew over many cells",
+			ops:   []gridOp{{id: 1, circle: testCircle{0, 0, 1}}, {id: 1, circle: testCircle{0, 0, 45}}},
+			query: testCircle{40, 0, 1},
+			want:  []uint64{1},
+		},
+		{
+			name:  "removed",
+			ops:   []gridOp{{id: 1, circle: testCircle{5, 5, 1}}, {id: 2, circle: testCircle{6, 6, 1}}, {id: 1, remove: true}},
+			query: testCircle{5, 5, 3},
+			want:  []uint64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := NewSpatialGrid(10)
+			reference := bruteForce{}
+
+			for _, op := range tt.ops {
+				if op.remove {
+					grid.Remove(op.id)
+					delete(reference, op.id)
+					continue
+				}
+				grid.Update(op.id, op.circle.x, op.circle.y, op.circle.radius)
+				reference[op.id] = op.circle
+			}
+
+			q := tt.query
+			got := grid.QueryRadius(q.x, q.y, q.radius)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("QueryRadius = %v, want %v", got, tt.want)
+			}
+			if want := reference.queryRadius(q.x, q.y, q.radius); !slices.Equal(got, want) {
+				t.Errorf("QueryRadius = %v, brute force found %v", got, want)
+			}
+			if want := reference.queryRect(q.x-q.radius, q.y-q.radius, q.x+q.radius, q.y+q.radius); !slices.Equal(grid.QueryRect(q.x-q.radius, q.y-q.radius, q.x+q.radius, q.y+q.radius), want) {
+				t.Errorf("QueryRect disagrees with brute force %v", want)
+			}
+			if grid.Len() != len(reference) {
+				t.Errorf("Len = %d, want %d", grid.Len(), len(reference))
+			}
+		})
+	}
+}
+
+// 删除所有对象之后不留下空的格子
+func TestSpatialGridRemoveFreesCells(t *testing.T) {
+	grid := NewSpatialGrid(10)
+	grid.Update(1, 0, 0, 25)
+	grid.Update(2, 3, 3, 1)
+	grid.Update(1, 100, 100, 1)
+	grid.Remove(1)
+	grid.Remove(2)
+	grid.Remove(3)
+
+	if grid.Len() != 0 || len(grid.cells) != 0 {
+		t.Errorf("got %d entries in %d cells after removing everything", grid.Len(), len(grid.cells))
+	}
+}
+
+// 随机的插入、移动和删除之后，两种查询都和逐个检查的结果一样
+func TestSpatialGridMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	coord := func() float64 { return rng.Float64()*200 - 100 }
+
+	grid := NewSpatialGrid(16)
+	reference := bruteForce{}
+
+	for step := 0; step < 2000; step++ {
+		id := uint64(rng.IntN(100))
+
+		if rng.IntN(5) == 0 {
+			grid.Remove(id)
+			delete(reference, id)
+		} else {
+			c := testCircle{x: coord(), y: coord(), radius: rng.Float64() * 20}
+			// 一部分对象只移动一点，经常停在原来的格子里
+			if old, exists := reference[id]; exists && rng.IntN(2) == 0 {
+				c.x, c.y = old.x+rng.Float64()*4-2, old.y+rng.Float64()*4-2
+			}
+			grid.Update(id, c.x, c.y, c.radius)
+			reference[id] = c
+		}
+
+		x, y, radius := coord(), coord(), rng.Float64()*40
+		if got, want := grid.QueryRadius(x, y, radius), reference.queryRadius(x, y, radius); !slices.Equal(got, want) {
+			t.Fatalf("step %d: QueryRadius(%f, %f, %f) = %v, want %v", step, x, y, radius, got, want)
+		}
+
+		minX, minY := coord(), coord()
+		maxX, maxY := minX+rng.Float64()*80, minY+rng.Float64()*80
+		if got, want := grid.QueryRect(minX, minY, maxX, maxY), reference.queryRect(minX, minY, maxX, maxY); !slices.Equal(got, want) {
+			t.Fatalf("step %d: QueryRect(%f, %f, %f, %f) = %v, want %v", step, minX, minY, maxX, maxY, got, want)
+		}
+
+		if grid.Len() != len(reference) {
+			t.Fatalf("step %d: Len = %d, want %d", step, grid.Len(), len(reference))
+		}
+	}
+}
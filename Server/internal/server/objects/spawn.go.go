@@ -4,13 +4,8 @@ import (
 	"math/rand/v2"
 )
 
-var getPlayerPosition = func(p *Player) (float64, float64) { return p.X, p.Y }
-var getPlayerRadius = func(p *Player) float64 { return p.Radius }
-var getSporePosition = func(s *Spore) (float64, float64) { return s.X, s.Y }
-var getSporeRadius = func(s *Spore) float64 { return s.Radius }
-
-func SpawnCoords(radius float64, playersToAvoid *SharedCollection[*Player], sporesToAvoid *SharedCollection[*Spore]) (float64, float64) {
-	var bound float64 = 3000
+// 在 [-bound, bound] 的范围内找一个不和其他对象重叠的位置，找不到就扩大范围
+func SpawnCoords(radius float64, bound float64, playersToAvoid *SpatialCollection[*Player], sporesToAvoid *SpatialCollection[*Spore]) (float64, float64) {
 	const maxTries int = 25
 
 	tries := 0
@@ -18,8 +13,8 @@ func SpawnCoords(radius float64, playersToAvoid *SharedCollection[*Player], spor
 		x := bound * (2*rand.Float64() - 1)
 		y := bound * (2*rand.Float64() - 1)
 
-		if !isTooClose(x, y, radius, playersToAvoid, getPlayerPosition, getPlayerRadius) &&
-			!isTooClose(x, y, radius, sporesToAvoid, getSporePosition, getSporeRadius) {
+		if !isTooClose(x, y, radius, playersToAvoid) &&
+			!isTooClose(x, y, radius, sporesToAvoid) {
 			return x, y
 		}
 
@@ -31,30 +26,12 @@ func SpawnCoords(radius float64, playersToAvoid *SharedCollection[*Player], spor
 	}
 }
 
-func isTooClose[T any](x float64, y float64, radius float64, objects *SharedCollection[T], getPosition func(T) (float64, float64), getRadius func(T) float64) bool {
+func isTooClose[T Bounded](x float64, y float64, radius float64, objects *SpatialCollection[T]) bool {
 	// Not too close if there are no objects
 	if objects == nil {
 		return false
 	}
 
-	// Check if any object is too close
-	tooClose := false
-	objects.ForEach(func(_ uint64, object T) {
-		if tooClose {
-			return
-		}
-
-		objX, objY := getPosition(object)
-		objRad := getRadius(object)
-		xDst := objX - x
-		yDst := objY - y
-		dstSq := xDst*xDst + yDst*yDst
-
-		if dstSq <= (radius+objRad)*(radius+objRad) {
-			tooClose = true
-			return
-		}
-	})
-
-	return tooClose
+	// Only the objects in the nearby grid cells need to be checked
+	return objects.AnyInRadius(x, y, radius)
 }
@@ -0,0 +1,48 @@
+package server
+
+import "sync"
+
+// A thread-safe record of each player's biggest radius in the current game. Player objects
+// in a room are only read and written on the hub's goroutine, so the hub records every growth
+// here and client states take their stats and death mass from it instead of the player.
+type PeakRadii struct {
+	radii map[uint64]float64
+	mux   sync.Mutex
+}
+
+func NewPeakRadii() *PeakRadii {
+	return &PeakRadii{
+		radii: make(map[uint64]float64),
+	}
+}
+
+// 开始新的一局，之前的记录作废
+func (p *PeakRadii) Start(playerId uint64, radius float64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.radii[playerId] = radius
+}
+
+// 玩家变大了，只保留最大的
+func (p *PeakRadii) Record(playerId uint64, radius float64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.radii[playerId] = max(p.radii[playerId], radius)
+}
+
+func (p *PeakRadii) Get(playerId uint64) (float64, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	radius, exists := p.radii[playerId]
+	return radius, exists
+}
+
+func (p *PeakRadii) Remove(playerId uint64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	delete(p.radii, playerId)
+}
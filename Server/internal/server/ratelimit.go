@@ -0,0 +1,265 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"server/internal/server/config"
+)
+
+// 多久清理一次过期的记录
+const rateLimitPruneInterval = time.Minute
+
+// 一种操作的限制：允许的失败次数、之后翻倍的等待时间和锁定
+type AttemptPolicy struct {
+	// 超过这么多次之后开始等待
+	FreeAttempts int
+	// 第一次的等待时间，之后每次翻倍，最多 MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// 达到这么多次之后锁定 LockoutDuration
+	LockoutAttempts int
+	LockoutDuration time.Duration
+	// 这么久没有新的失败就清零
+	ResetAfter time.Duration
+}
+
+// 某个 IP 或者用户名的失败记录
+type attemptRecord struct {
+	Failures     int       `json:"failures"`
+	BlockedUntil time.Time `json:"blocked_until"`
+	LockedOut    bool      `json:"locked_out"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// 被限制时告诉客户端还要等多久
+type RateLimitError struct {
+	Wait      time.Duration
+	LockedOut bool
+}
+
+func (e *RateLimitError) Error() string {
+	wait := e.Wait.Round(time.Second)
+	if wait < time.Second {
+		wait = time.Second
+	}
+
+	if e.LockedOut {
+		return fmt.Sprintf("Too many failed attempts - locked out, try again in %s", wait)
+	}
+	return fmt.Sprintf("Too many attempts - try again in %s", wait)
+}
+
+// A thread-safe, in-memory record of failed logins and registrations per IP and per
+// username. Records can be saved to a JSON file on shutdown and loaded on startup so a
+// restart doesn't lift a lockout.
+type RateLimiter struct {
+	login    AttemptPolicy
+	register AttemptPolicy
+	// 不按 IP 限制的网段
+	allowlist []netip.Prefix
+
+	records   map[string]*attemptRecord
+	nextPrune time.Time
+	mux       sync.Mutex
+}
+
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	policy := func(freeAttempts int, lockoutAttempts int) AttemptPolicy {
+		return AttemptPolicy{
+			FreeAttempts:    freeAttempts,
+			Backoff:         cfg.Backoff.Duration(),
+			MaxBackoff:      cfg.MaxBackoff.Duration(),
+			LockoutAttempts: lockoutAttempts,
+			LockoutDuration: cfg.LockoutDuration.Duration(),
+			ResetAfter:      cfg.ResetAfter.Duration(),
+		}
+	}
+
+	// 配置已经检查过，解析不了的地址不会走到这里
+	allowlist, _ := cfg.AllowedPrefixes()
+
+	return &RateLimiter{
+		login:     policy(cfg.LoginAttempts, cfg.LoginLockoutAttempts),
+		register:  policy(cfg.RegisterAttempts, cfg.RegisterLockoutAttempts),
+		allowlist: allowlist,
+		records:   make(map[string]*attemptRecord),
+	}
+}
+
+// 白名单里的地址和进程内的客户端一样当成没有 IP
+func (l *RateLimiter) limitedIP(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	for _, prefix := range l.allowlist {
+		if prefix.Contains(addr.Unmap()) {
+			return ""
+		}
+	}
+	return ip
+}
+
+// 进程内的客户端（机器人、测试）没有 IP，只按用户名限制
+func loginKeys(ip string, username string) []string {
+	keys := []string{"login-user:" + strings.ToLower(username)}
+	if ip != "" {
+		keys = append(keys, "login-ip:"+ip)
+	}
+	return keys
+}
+
+func registerKeys(ip string) []string {
+	if ip == "" {
+		return nil
+	}
+	return []string{"register-ip:" + ip}
+}
+
+// 登录（以及需要密码的账号操作）之前检查，被限制时返回 *RateLimitError
+func (l *RateLimiter) CheckLogin(ip string, username string) error {
+	return l.check(time.Now(), loginKeys(l.limitedIP(ip), username))
+}
+
+// 密码错误或者用户不存在
+func (l *RateLimiter) FailLogin(ip string, username string) {
+	l.fail(time.Now(), l.login, loginKeys(l.limitedIP(ip), username))
+}
+
+// 登录成功之后清掉这个用户名的记录，IP 的记录保留
+func (l *RateLimiter) SucceedLogin(username string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	delete(l.records, loginKeys("", username)[0])
+}
+
+// 注册之前检查这个 IP
+func (l *RateLimiter) CheckRegister(ip string) error {
+	return l.check(time.Now(), registerKeys(l.limitedIP(ip)))
+}
+
+// 每次真正查询数据库的注册都算一次，不管成功与否
+func (l *RateLimiter) RecordRegister(ip string) {
+	l.fail(time.Now(), l.register, registerKeys(l.limitedIP(ip)))
+}
+
+func (l *RateLimiter) check(now time.Time, keys []string) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	var worst *RateLimitError
+	for _, key := range keys {
+		record, exists := l.records[key]
+		if !exists || !now.Before(record.BlockedUntil) || !now.Before(record.ExpiresAt) {
+			continue
+		}
+
+		wait := record.BlockedUntil.Sub(now)
+		if worst == nil || wait > worst.Wait {
+			worst = &RateLimitError{Wait: wait, LockedOut: record.LockedOut}
+		}
+	}
+
+	if worst == nil {
+		return nil
+	}
+	return worst
+}
+
+func (l *RateLimiter) fail(now time.Time, policy AttemptPolicy, keys []string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.pruneLocked(now)
+
+	for _, key := range keys {
+		record, exists := l.records[key]
+		if !exists || !now.Before(record.ExpiresAt) {
+			record = &attemptRecord{}
+			l.records[key] = record
+		}
+
+		record.Failures++
+		record.LockedOut = false
+
+		switch {
+		case policy.LockoutAttempts > 0 && record.Failures >= policy.LockoutAttempts:
+			record.BlockedUntil = now.Add(policy.LockoutDuration)
+			record.LockedOut = true
+		case record.Failures > policy.FreeAttempts:
+			backoff := policy.Backoff
+			for i := policy.FreeAttempts + 1; i < record.Failures && backoff < policy.MaxBackoff; i++ {
+				backoff *= 2
+			}
+			record.BlockedUntil = now.Add(min(backoff, policy.MaxBackoff))
+		}
+
+		record.ExpiresAt = now.Add(policy.ResetAfter)
+		if record.BlockedUntil.After(record.ExpiresAt) {
+			record.ExpiresAt = record.BlockedUntil
+		}
+	}
+}
+
+// 调用方需要持有锁
+func (l *RateLimiter) pruneLocked(now time.Time) {
+	if now.Before(l.nextPrune) {
+		return
+	}
+	l.nextPrune = now.Add(rateLimitPruneInterval)
+
+	for key, record := range l.records {
+		if !now.Before(record.ExpiresAt) {
+			delete(l.records, key)
+		}
+	}
+}
+
+// Load reads records saved by Save. A missing file is not an error.
+func (l *RateLimiter) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	records := make(map[string]*attemptRecord)
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("parsing rate limit file %s: %w", path, err)
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	now := time.Now()
+	for key, record := range records {
+		if now.Before(record.ExpiresAt) {
+			l.records[key] = record
+		}
+	}
+	return nil
+}
+
+// Save writes the records that haven't expired yet to path.
+func (l *RateLimiter) Save(path string) error {
+	l.mux.Lock()
+	l.nextPrune = time.Time{}
+	l.pruneLocked(time.Now())
+	data, err := json.MarshalIndent(l.records, "", "  ")
+	l.mux.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
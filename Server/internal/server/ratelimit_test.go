@@ -0,0 +1,186 @@
+package server
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"server/internal/server/config"
+)
+
+func newTestRateLimiter() *RateLimiter {
+	return NewRateLimiter(config.RateLimitConfig{
+		LoginAttempts:           2,
+		LoginLockoutAttempts:    5,
+		RegisterAttempts:        1,
+		RegisterLockoutAttempts: 3,
+		Backoff:                 config.Duration(time.Second),
+		MaxBackoff:              config.Duration(4 * time.Second),
+		LockoutDuration:         config.Duration(time.Hour),
+		ResetAfter:              config.Duration(10 * time.Minute),
+	})
+}
+
+// 每次失败之后要等待的时间，没有限制时为 0
+func waitAfter(t *testing.T, err error) (time.Duration, bool) {
+	t.Helper()
+
+	if err == nil {
+		return 0, false
+	}
+	var limitErr *RateLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("got error %v, want *RateLimitError", err)
+	}
+	return limitErr.Wait, limitErr.LockedOut
+}
+
+func TestRateLimiterLoginBackoff(t *testing.T) {
+	limiter := newTestRateLimiter()
+	now := time.Now()
+	keys := loginKeys("10.0.0.1", "Alice")
+
+	wantWaits := []time.Duration{0, 0, time.Second, 2 * time.Second, 4 * time.Second}
+	for i, want := range wantWaits {
+		limiter.fail(now, limiter.login, keys)
+
+		wait, lockedOut := waitAfter(t, limiter.check(now, keys))
+		if i == len(wantWaits)-1 {
+			if !lockedOut || wait != time.Hour {
+				t.Fatalf("failure %d: got wait %s locked out %v, want an hour lockout", i+1, wait, lockedOut)
+			}
+			continue
+		}
+		if wait != want || lockedOut {
+			t.Errorf("failure %d: got wait %s locked out %v, want %s", i+1, wait, lockedOut, want)
+		}
+	}
+
+	// 用户名不分大小写，同一个 IP 上的其他用户名也被限制
+	if err := limiter.CheckLogin("10.0.0.1", "bob"); err == nil {
+		t.Error("other username on the same IP is not limited")
+	}
+	if err := limiter.CheckLogin("10.0.0.2", "ALICE"); err == nil {
+		t.Error("same username on another IP is not limited")
+	}
+	if err := limiter.CheckLogin("10.0.0.2", "bob"); err != nil {
+		t.Errorf("unrelated login is limited: %v", err)
+	}
+
+	// 锁定结束之后就清零了
+	later := now.Add(time.Hour + time.Second)
+	if err := limiter.check(later, keys); err != nil {
+		t.Errorf("still limited after the lockout: %v", err)
+	}
+	limiter.fail(later, limiter.login, keys)
+	if err := limiter.check(later, keys); err != nil {
+		t.Errorf("first failure after the reset is limited: %v", err)
+	}
+}
+
+func TestRateLimiterSucceedLogin(t *testing.T) {
+	limiter := newTestRateLimiter()
+	for range 3 {
+		limiter.FailLogin("10.0.0.1", "alice")
+	}
+
+	limiter.SucceedLogin("ALICE")
+
+	if err := limiter.CheckLogin("", "alice"); err != nil {
+		t.Errorf("username still limited after a successful login: %v", err)
+	}
+	if err := limiter.CheckLogin("10.0.0.1", "bob"); err == nil {
+		t.Error("a successful login should not clear the IP")
+	}
+}
+
+func TestRateLimiterRegister(t *testing.T) {
+	limiter := newTestRateLimiter()
+
+	limiter.RecordRegister("10.0.0.1")
+	if err := limiter.CheckRegister("10.0.0.1"); err != nil {
+		t.Fatalf("first registration is limited: %v", err)
+	}
+
+	limiter.RecordRegister("10.0.0.1")
+	if err := limiter.CheckRegister("10.0.0.1"); err == nil {
+		t.Error("second registration is not limited")
+	}
+	if err := limiter.CheckRegister("10.0.0.2"); err != nil {
+		t.Errorf("another IP is limited: %v", err)
+	}
+
+	// 进程内的客户端没有 IP，不限制注册
+	for range 10 {
+		limiter.RecordRegister("")
+	}
+	if err := limiter.CheckRegister(""); err != nil {
+		t.Errorf("client without an IP is limited: %v", err)
+	}
+}
+
+func TestRateLimiterSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_limits.json")
+
+	limiter := newTestRateLimiter()
+	for range 5 {
+		limiter.FailLogin("10.0.0.1", "alice")
+	}
+	if err := limiter.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := newTestRateLimiter()
+	if err := restarted.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, lockedOut := waitAfter(t, restarted.CheckLogin("", "alice")); !lockedOut {
+		t.Error("lockout was lost across a restart")
+	}
+
+	// 没有文件时从空的记录开始
+	if err := newTestRateLimiter().Load(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Errorf("loading a missing file: %v", err)
+	}
+}
+
+// 白名单里的地址不按 IP 限制，登录仍然按用户名限制
+func TestRateLimiterAllowlist(t *testing.T) {
+	cfg := config.Default().RateLimit
+	cfg.RegisterAttempts = 1
+	cfg.LoginAttempts = 1
+	cfg.Allowlist = []string{"127.0.0.0/8", "::1"}
+	limiter := NewRateLimiter(cfg)
+
+	tests := []struct {
+		ip      string
+		allowed bool
+	}{
+		{ip: "127.0.0.1", allowed: true},
+		{ip: "127.1.2.3", allowed: true},
+		{ip: "::1", allowed: true},
+		{ip: "::ffff:127.0.0.1", allowed: true},
+		{ip: "10.0.0.1", allowed: false},
+	}
+	for _, test := range tests {
+		t.Run(test.ip, func(t *testing.T) {
+			for range 5 {
+				limiter.RecordRegister(test.ip)
+			}
+			if err := limiter.CheckRegister(test.ip); (err == nil) != test.allowed {
+				t.Errorf("got register error %v, want allowed %t", err, test.allowed)
+			}
+		})
+	}
+
+	for range 3 {
+		limiter.FailLogin("127.0.0.1", "alice")
+	}
+	if err := limiter.CheckLogin("127.0.0.1", "bob"); err != nil {
+		t.Errorf("allowlisted IP is limited for another user: %v", err)
+	}
+	if err := limiter.CheckLogin("127.0.0.1", "alice"); err == nil {
+		t.Error("username is not limited from an allowlisted IP")
+	}
+}
@@ -0,0 +1,317 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"server/internal/server/config"
+	"server/internal/server/objects"
+)
+
+var (
+	ErrRoomNotFound = errors.New("room not found")
+	ErrRoomFull     = errors.New("room is full")
+	// 所有房间都满了，也不能再创建新的房间
+	ErrNoRoomAvailable = errors.New("every room is full")
+)
+
+// 一个竞技场：自己的玩家和孢子，模拟、视野、广播和排行榜都只在房间里面
+type Room struct {
+	Id       uint64
+	Capacity int
+
+	SharedGameObjects *SharedGameObjects
+
+	// 人满时自动创建的房间，空了之后删除
+	automatic bool
+
+	// 房间里的玩家 ID（包括等待重连的），由 RoomManager 的锁保护
+	members map[uint64]struct{}
+}
+
+// 房间列表里的一项
+type RoomInfo struct {
+	Id       uint64
+	Players  int
+	Capacity int
+}
+
+// A thread-safe set of rooms. Every logged-in player is a member of exactly one room,
+// from joining until it leaves the game for good (disconnected players keep their place
+// until their session expires).
+type RoomManager struct {
+	config *config.Config
+
+	rooms    map[uint64]*Room
+	byPlayer map[uint64]*Room
+	nextId   uint64
+	mux      sync.Mutex
+}
+
+// NewRoomManager creates the rooms.initial permanent rooms and fills them with spores.
+func NewRoomManager(cfg *config.Config) *RoomManager {
+	m := &RoomManager{
+		config:   cfg,
+		rooms:    make(map[uint64]*Room),
+		byPlayer: make(map[uint64]*Room),
+		nextId:   1,
+	}
+
+	for i := 0; i < cfg.Rooms.Initial; i++ {
+		m.addRoomLocked(m.newRoom(false))
+	}
+	return m
+}
+
+// 新建一个放好孢子的房间，还没有 ID。要放 max_spores 个孢子，所以不能拿着锁
+func (m *RoomManager) newRoom(automatic bool) *Room {
+	room := &Room{
+		Capacity: m.config.Rooms.Capacity,
+		SharedGameObjects: &SharedGameObjects{
+			Players: objects.NewSpatialCollection[*objects.Player](objects.DefaultCellSize),
+			Spores:  objects.NewSpatialCollection[*objects.Spore](objects.DefaultCellSize, m.config.World.MaxSpores), //生成一个孢子池的对象
+		},
+		automatic: automatic,
+		members:   make(map[uint64]struct{}),
+	}
+
+	for i := 0; i < m.config.World.MaxSpores; i++ {
+		room.SharedGameObjects.Spores.Add(room.NewSpore(m.config.World.SpawnBound))
+	}
+
+	return room
+}
+
+// 给新房间分配 ID 并加入列表，调用方需要持有锁（或者还没有共享出去）
+func (m *RoomManager) addRoomLocked(room *Room) {
+	room.Id = m.nextId
+	m.nextId++
+	m.rooms[room.Id] = room
+
+	log.Printf("Created room %d", room.Id)
+}
+
+func (m *RoomManager) Get(id uint64) (*Room, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	room, exists := m.rooms[id]
+	return room, exists
+}
+
+// 玩家所在的房间
+func (m *RoomManager) RoomOf(playerId uint64) (*Room, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	room, exists := m.byPlayer[playerId]
+	return room, exists
+}
+
+// 按 ID 排序的所有房间
+func (m *RoomManager) Rooms() []*Room {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	rooms := make([]*Room, 0, len(m.rooms))
+	for _, room := range m.rooms {
+		rooms = append(rooms, room)
+	}
+	slices.SortFunc(rooms, func(a, b *Room) int {
+		return int(a.Id) - int(b.Id)
+	})
+	return rooms
+}
+
+// 给客户端看的房间列表，按 ID 排序
+func (m *RoomManager) List() []RoomInfo {
+	rooms := m.Rooms()
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	infos := make([]RoomInfo, 0, len(rooms))
+	for _, room := range rooms {
+		infos = append(infos, RoomInfo{Id: room.Id, Players: len(room.members), Capacity: room.Capacity})
+	}
+	return infos
+}
+
+// 一个房间现在的人数
+func (m *RoomManager) Info(room *Room) RoomInfo {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return RoomInfo{Id: room.Id, Players: len(room.members), Capacity: room.Capacity}
+}
+
+// Join moves the player into the room with the given ID, taking its player out of the
+// room it was in before. Joining the room the player is already in does nothing.
+func (m *RoomManager) Join(playerId uint64, roomId uint64) (*Room, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	room, exists := m.rooms[roomId]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+	if m.byPlayer[playerId] == room {
+		return room, nil
+	}
+	if len(room.members) >= room.Capacity {
+		return nil, ErrRoomFull
+	}
+
+	m.leaveLocked(playerId)
+	m.joinLocked(playerId, room)
+	return room, nil
+}
+
+// JoinAny puts the player into the first room with space, creating a new room when every
+// room is full. A player that is already in a room stays there.
+func (m *RoomManager) JoinAny(playerId uint64) (*Room, error) {
+	return m.withNewRoom(func(spare *Room) (*Room, bool, error) {
+		return m.joinAnyLocked(playerId, spare)
+	})
+}
+
+// 需要新房间时在锁外面建好，再拿着锁重新试一次，其他玩家可能已经改变了房间的情况。
+// try 在锁里执行，spare 是建好的新房间（第一次为 nil），需要新房间时返回 true
+func (m *RoomManager) withNewRoom(try func(spare *Room) (*Room, bool, error)) (*Room, error) {
+	var spare *Room
+	for {
+		m.mux.Lock()
+		room, needRoom, err := try(spare)
+		m.mux.Unlock()
+
+		if !needRoom {
+			return room, err
+		}
+		spare = m.newRoom(true)
+	}
+}
+
+// 调用方需要持有锁
+func (m *RoomManager) joinAnyLocked(playerId uint64, spare *Room) (*Room, bool, error) {
+	if room, exists := m.byPlayer[playerId]; exists {
+		return room, false, nil
+	}
+
+	ids := make([]uint64, 0, len(m.rooms))
+	for id := range m.rooms {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		if room := m.rooms[id]; len(room.members) < room.Capacity {
+			m.joinLocked(playerId, room)
+			return room, false, nil
+		}
+	}
+
+	if len(m.rooms) >= m.config.Rooms.MaxRooms {
+		return nil, false, ErrNoRoomAvailable
+	}
+	if spare == nil {
+		return nil, true, nil
+	}
+
+	m.addRoomLocked(spare)
+	m.joinLocked(playerId, spare)
+	return spare, false, nil
+}
+
+// JoinGroup puts a matched group of players into one room, taking them out of the rooms they
+// were in before. It prefers an empty room so the group plays on its own, then a new room,
+// then the first room with space for everyone.
+func (m *RoomManager) JoinGroup(playerIds []uint64) (*Room, error) {
+	return m.withNewRoom(func(spare *Room) (*Room, bool, error) {
+		return m.joinGroupLocked(playerIds, spare)
+	})
+}
+
+// 调用方需要持有锁
+func (m *RoomManager) joinGroupLocked(playerIds []uint64, spare *Room) (*Room, bool, error) {
+	ids := make([]uint64, 0, len(m.rooms))
+	for id := range m.rooms {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var room *Room
+	for _, id := range ids {
+		if candidate := m.rooms[id]; len(candidate.members) == 0 && candidate.Capacity >= len(playerIds) {
+			room = candidate
+			break
+		}
+	}
+	if room == nil && len(m.rooms) < m.config.Rooms.MaxRooms && m.config.Rooms.Capacity >= len(playerIds) {
+		if spare == nil {
+			return nil, true, nil
+		}
+		m.addRoomLocked(spare)
+		room = spare
+	}
+	if room == nil {
+		for _, id := range ids {
+			if candidate := m.rooms[id]; candidate.Capacity-len(candidate.members) >= len(playerIds) {
+				room = candidate
+				break
+			}
+		}
+	}
+	if room == nil {
+		return nil, false, ErrNoRoomAvailable
+	}
+
+	for _, playerId := range playerIds {
+		if m.byPlayer[playerId] != room {
+			m.leaveLocked(playerId)
+			m.joinLocked(playerId, room)
+		}
+	}
+	return room, false, nil
+}
+
+// Leave takes the player and its member slot out of its room. Automatically created rooms
+// are removed once the last player leaves.
+func (m *RoomManager) Leave(playerId uint64) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.leaveLocked(playerId)
+}
+
+// 调用方需要持有锁
+func (m *RoomManager) joinLocked(playerId uint64, room *Room) {
+	room.members[playerId] = struct{}{}
+	m.byPlayer[playerId] = room
+}
+
+// 调用方需要持有锁
+func (m *RoomManager) leaveLocked(playerId uint64) {
+	room, exists := m.byPlayer[playerId]
+	if !exists {
+		return
+	}
+
+	room.SharedGameObjects.Players.Remove(playerId)
+	delete(room.members, playerId)
+	delete(m.byPlayer, playerId)
+
+	if room.automatic && len(room.members) == 0 {
+		log.Printf("Room %d is empty, removing it", room.Id)
+		delete(m.rooms, room.Id)
+	}
+}
+
+// 新建一个孢子
+func (r *Room) NewSpore(spawnBound float64) *objects.Spore {
+	sporeRadius := max(rand.NormFloat64()*3+10, 5)
+	x, y := objects.SpawnCoords(sporeRadius, spawnBound, r.SharedGameObjects.Players, r.SharedGameObjects.Spores) //将玩家传进去，这样就
+	return &objects.Spore{X: x, Y: y, Radius: sporeRadius}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"server/internal/server/config"
+	"server/internal/server/objects"
+)
+
+func newTestRoomManager(capacity int, initial int, maxRooms int) *RoomManager {
+	cfg := config.Default()
+	cfg.World.MaxSpores = 3
+	cfg.Rooms = config.RoomsConfig{Capacity: capacity, Initial: initial, MaxRooms: maxRooms}
+	return NewRoomManager(cfg)
+}
+
+func roomIds(m *RoomManager) []uint64 {
+	var ids []uint64
+	for _, room := range m.Rooms() {
+		ids = append(ids, room.Id)
+	}
+	return ids
+}
+
+func TestRoomManagerJoinAny(t *testing.T) {
+	m := newTestRoomManager(2, 1, 2)
+
+	if got := roomIds(m); len(got) != 1 {
+		t.Fatalf("got rooms %v, want one initial room", got)
+	}
+	if m.Rooms()[0].SharedGameObjects.Spores.Len() != 3 {
+		t.Error("initial room was not filled with spores")
+	}
+
+	for id := uint64(1); id <= 2; id++ {
+		if room, err := m.JoinAny(id); err != nil || room.Id != 1 {
+			t.Fatalf("player %d: got room %v, error %v, want room 1", id, room, err)
+		}
+	}
+
+	// 第一个房间满了，自动创建第二个
+	room, err := m.JoinAny(3)
+	if err != nil || room.Id != 2 {
+		t.Fatalf("got room %v, error %v, want a new room 2", room, err)
+	}
+	if room.SharedGameObjects.Spores.Len() != 3 {
+		t.Error("new room was not filled with spores")
+	}
+
+	// 已经在房间里的玩家留在原来的房间
+	if room, _ := m.JoinAny(1); room.Id != 1 {
+		t.Errorf("player 1 moved to room %d", room.Id)
+	}
+
+	m.JoinAny(4)
+	if _, err := m.JoinAny(5); !errors.Is(err, ErrNoRoomAvailable) {
+		t.Errorf("got error %v, want ErrNoRoomAvailable", err)
+	}
+
+	// 自动创建的房间空了之后删除，启动时的房间保留
+	m.Leave(3)
+	m.Leave(4)
+	m.Leave(1)
+	m.Leave(2)
+	if got := roomIds(m); len(got) != 1 || got[0] != 1 {
+		t.Errorf("got rooms %v, want only room 1", got)
+	}
+}
+
+func TestRoomManagerJoin(t *testing.T) {
+	m := newTestRoomManager(1, 2, 2)
+
+	m.JoinAny(1)
+	m.Rooms()[0].SharedGameObjects.Players.Add(&objects.Player{Name: "alice"}, 1)
+
+	if _, err := m.Join(2, 1); !errors.Is(err, ErrRoomFull) {
+		t.Errorf("got error %v, want ErrRoomFull", err)
+	}
+	if _, err := m.Join(2, 42); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("got error %v, want ErrRoomNotFound", err)
+	}
+
+	// 换房间时玩家从旧房间里删除
+	room, err := m.Join(1, 2)
+	if err != nil || room.Id != 2 {
+		t.Fatalf("got room %v, error %v, want room 2", room, err)
+	}
+	if _, exists := m.Rooms()[0].SharedGameObjects.Players.Get(1); exists {
+		t.Error("player is still in the old room")
+	}
+	if current, _ := m.RoomOf(1); current != room {
+		t.Error("RoomOf does not return the new room")
+	}
+
+	list := m.List()
+	if len(list) != 2 || list[0].Players != 0 || list[1].Players != 1 || list[1].Capacity != 1 {
+		t.Errorf("got list %+v", list)
+	}
+}
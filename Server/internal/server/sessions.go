@@ -0,0 +1,208 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+type sessionState int
+
+const (
+	// 客户端在线
+	sessionActive sessionState = iota
+	// 连接正在关闭，玩家保留在世界里
+	sessionClosing
+	// Hub 已经注销了旧的连接，可以重连
+	sessionDetached
+)
+
+// 登录之后的会话，玩家 ID 和客户端 ID 相同
+type Session struct {
+	Token      string
+	Username   string
+	PlayerId   uint64
+	state      sessionState
+	detachedAt time.Time
+}
+
+// 会话的连接还在线
+func (s Session) Online() bool {
+	return s.state == sessionActive
+}
+
+// A thread-safe store of login sessions, used to reattach a new connection to a player
+// that is still in the world after its websocket dropped.
+type SessionStore struct {
+	// 断线之后玩家在世界里保留的时间，超过之后就不能重连了
+	gracePeriod time.Duration
+
+	byToken  map[string]*Session
+	byPlayer map[uint64]*Session
+	mux      sync.Mutex
+}
+
+func NewSessionStore(gracePeriod time.Duration) *SessionStore {
+	return &SessionStore{
+		gracePeriod: gracePeriod,
+		byToken:     make(map[string]*Session),
+		byPlayer:    make(map[uint64]*Session),
+	}
+}
+
+// 账号已经在另一个连接上登录，并且没有要求顶掉它
+var ErrAlreadyLoggedIn = errors.New("account is already logged in")
+
+// 登录成功后创建会话，返回会话令牌。同一个账号（用户名不分大小写）只能有一个会话：
+// 旧会话的连接已经断开时直接替换；还在线时 replaceOnline 为 false 返回 ErrAlreadyLoggedIn，
+// 为 true 时替换。被替换的会话作为 replaced 返回，调用方负责踢掉它的客户端或者删除它的玩家
+func (s *SessionStore) Create(username string, playerId uint64, replaceOnline bool) (token string, replaced *Session, err error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", nil, err
+	}
+	token = hex.EncodeToString(tokenBytes)
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if existing := s.findUserLocked(username); existing != nil {
+		if existing.state == sessionActive && !replaceOnline {
+			return "", nil, ErrAlreadyLoggedIn
+		}
+
+		copied := *existing
+		replaced = &copied
+		s.removeLocked(existing.PlayerId)
+	}
+
+	s.removeLocked(playerId)
+
+	session := &Session{Token: token, Username: username, PlayerId: playerId}
+	s.byToken[token] = session
+	s.byPlayer[playerId] = session
+
+	return token, replaced, nil
+}
+
+// 连接开始关闭，之后的 OnExit 会保留玩家
+func (s *SessionStore) BeginClose(playerId uint64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if session, exists := s.byPlayer[playerId]; exists && session.state == sessionActive {
+		session.state = sessionClosing
+	}
+}
+
+// Hub 注销旧连接之后才允许重连，避免和旧连接的 OnExit 冲突
+func (s *SessionStore) Detach(playerId uint64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if session, exists := s.byPlayer[playerId]; exists && session.state == sessionClosing {
+		session.state = sessionDetached
+		session.detachedAt = time.Now()
+	}
+}
+
+// 玩家的连接已经断开（正在关闭或者等待重连）
+func (s *SessionStore) IsDisconnected(playerId uint64) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	session, exists := s.byPlayer[playerId]
+	return exists && session.state != sessionActive
+}
+
+// 这个账号是否还有会话（在线或者等待重连），用户名不分大小写
+func (s *SessionStore) HasUser(username string) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.findUserLocked(username) != nil
+}
+
+// 账号的会话的副本，用户名不分大小写
+func (s *SessionStore) Find(username string) (Session, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if session := s.findUserLocked(username); session != nil {
+		return *session, true
+	}
+	return Session{}, false
+}
+
+// 调用方需要持有锁
+func (s *SessionStore) findUserLocked(username string) *Session {
+	for _, session := range s.byPlayer {
+		if strings.EqualFold(session.Username, username) {
+			return session
+		}
+	}
+	return nil
+}
+
+// 用令牌重新连接，成功时返回会话的副本
+func (s *SessionStore) Reattach(token string) (Session, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	session, exists := s.byToken[token]
+	if !exists || session.state != sessionDetached || time.Since(session.detachedAt) > s.gracePeriod {
+		return Session{}, false
+	}
+
+	session.state = sessionActive
+	return *session, true
+}
+
+// 删除账号的会话（在线或者等待重连），令牌之后不能再用来重连，用户名不分大小写。
+// 返回被删除的会话的副本，调用方负责踢掉它的客户端或者删除它的玩家
+func (s *SessionStore) Revoke(username string) (Session, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	session := s.findUserLocked(username)
+	if session == nil {
+		return Session{}, false
+	}
+
+	revoked := *session
+	s.removeLocked(session.PlayerId)
+	return revoked, true
+}
+
+// 删除断线超过保留时间的会话，返回它们的玩家 ID
+func (s *SessionStore) Expire(now time.Time) []uint64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	var expired []uint64
+	for playerId, session := range s.byPlayer {
+		if session.state == sessionDetached && now.Sub(session.detachedAt) > s.gracePeriod {
+			expired = append(expired, playerId)
+			s.removeLocked(playerId)
+		}
+	}
+	return expired
+}
+
+func (s *SessionStore) Remove(playerId uint64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.removeLocked(playerId)
+}
+
+// 调用方需要持有锁
+func (s *SessionStore) removeLocked(playerId uint64) {
+	if session, exists := s.byPlayer[playerId]; exists {
+		delete(s.byToken, session.Token)
+		delete(s.byPlayer, playerId)
+	}
+}
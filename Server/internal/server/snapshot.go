@@ -0,0 +1,89 @@
+package server
+
+import (
+	"server/pkg/packets"
+	"slices"
+)
+
+// 为每个客户端保留最近发送的快照数量，确认的基准超过这个范围就重新发送完整快照
+const SnapshotHistorySize = 32
+
+// 发给客户端的一个快照
+type snapshot struct {
+	sequence uint64
+	players  map[uint64]*packets.PlayerMessage
+}
+
+// 一个客户端的快照记录，只在 Run 的协程里访问
+type snapshotHistory struct {
+	// 客户端协商了 snapshot_delta，否则每次都发完整快照
+	deltas   bool
+	sequence uint64
+	acked    uint64
+	sent     [SnapshotHistorySize]*snapshot
+}
+
+// 客户端确认收到了某个快照
+func (s *snapshotHistory) ack(sequence uint64) {
+	if sequence > s.acked && sequence <= s.sequence {
+		s.acked = sequence
+	}
+}
+
+// 客户端确认过并且还在记录里的快照，没有就返回 nil
+func (s *snapshotHistory) baseline() *snapshot {
+	if s.acked == 0 {
+		return nil
+	}
+
+	base := s.sent[s.acked%SnapshotHistorySize]
+	if base == nil || base.sequence != s.acked {
+		return nil
+	}
+	return base
+}
+
+// 记录这次的玩家状态，返回要发给客户端的完整快照或者差量
+func (s *snapshotHistory) next(players []*packets.PlayerMessage) packets.Msg {
+	s.sequence++
+
+	current := &snapshot{
+		sequence: s.sequence,
+		players:  make(map[uint64]*packets.PlayerMessage, len(players)),
+	}
+	for _, player := range players {
+		current.players[player.Id] = player
+	}
+
+	base := s.baseline()
+	s.sent[s.sequence%SnapshotHistorySize] = current
+
+	// 刚进入游戏、重连或者丢包太多时没有可用的基准
+	if !s.deltas || base == nil {
+		return packets.NewSnapshot(s.sequence, players)
+	}
+
+	var added []*packets.PlayerMessage
+	var changed []*packets.PlayerDeltaMessage
+	for _, player := range players {
+		old, exists := base.players[player.Id]
+		if !exists {
+			added = append(added, player)
+			continue
+		}
+
+		if delta := packets.NewPlayerDelta(old, player); delta != nil {
+			changed = append(changed, delta)
+		}
+	}
+
+	var removed []uint64
+	for id := range base.players {
+		if _, exists := current.players[id]; !exists {
+			removed = append(removed, id)
+		}
+	}
+	slices.Sort(removed)
+
+	return packets.NewSnapshotDelta(s.sequence, base.sequence, added, changed, removed)
+}
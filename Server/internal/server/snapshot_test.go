@@ -0,0 +1,152 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	"server/pkg/packets"
+)
+
+func testPlayer(id uint64, x float64) *packets.PlayerMessage {
+	return &packets.PlayerMessage{Id: id, Name: "player", X: x, Radius: 20, Speed: 150}
+}
+
+// 发出的快照的序号和基准，完整快照的基准是 0
+func snapshotSequences(t *testing.T, msg packets.Msg) (uint64, uint64) {
+	t.Helper()
+
+	switch msg := msg.(type) {
+	case *packets.Packet_Snapshot:
+		return msg.Snapshot.Sequence, 0
+	case *packets.Packet_SnapshotDelta:
+		return msg.SnapshotDelta.Sequence, msg.SnapshotDelta.BaseSequence
+	}
+	t.Fatalf("got %T, want a snapshot", msg)
+	return 0, 0
+}
+
+func TestSnapshotHistoryBaseline(t *testing.T) {
+	tests := []struct {
+		name   string
+		deltas bool
+		// 先发出这么多个快照，再按顺序确认 acks
+		sent int
+		acks []uint64
+		// 下一个快照的基准，0 表示完整快照
+		wantBase uint64
+	}{
+		{name: "nothing acked", deltas: true, sent: 3, wantBase: 0},
+		{name: "acked", deltas: true, sent: 3, acks: []uint64{2}, wantBase: 2},
+		{name: "latest ack", deltas: true, sent: 5, acks: []uint64{2, 4}, wantBase: 4},
+		{name: "older ack after a newer one", deltas: true, sent: 5, acks: []uint64{4, 2}, wantBase: 4},
+		{name: "duplicate ack", deltas: true, sent: 5, acks: []uint64{3, 3}, wantBase: 3},
+		{name: "zero ack", deltas: true, sent: 3, acks: []uint64{0}, wantBase: 0},
+		{name: "ack of an unsent snapshot", deltas: true, sent: 3, acks: []uint64{4}, wantBase: 0},
+		{name: "unsent ack after a valid one", deltas: true, sent: 3, acks: []uint64{2, 100}, wantBase: 2},
+		{name: "oldest snapshot in the ring", deltas: true, sent: SnapshotHistorySize, acks: []uint64{1}, wantBase: 1},
+		{name: "acked snapshot overwritten", deltas: true, sent: SnapshotHistorySize + 1, acks: []uint64{1}, wantBase: 0},
+		{name: "acked slot reused twice", deltas: true, sent: 2*SnapshotHistorySize + 5, acks: []uint64{SnapshotHistorySize + 5}, wantBase: 0},
+		{name: "recent ack after wrapping", deltas: true, sent: 2*SnapshotHistorySize + 5, acks: []uint64{2*SnapshotHistorySize + 1}, wantBase: 2*SnapshotHistorySize + 1},
+		{name: "deltas not negotiated", deltas: false, sent: 3, acks: []uint64{2}, wantBase: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &snapshotHistory{deltas: tt.deltas}
+			players := []*packets.PlayerMessage{testPlayer(1, 0)}
+
+			for i := 1; i <= tt.sent; i++ {
+				sequence, _ := snapshotSequences(t, history.next(players))
+				if sequence != uint64(i) {
+					t.Fatalf("snapshot %d has sequence %d", i, sequence)
+				}
+			}
+			for _, ack := range tt.acks {
+				history.ack(ack)
+			}
+
+			sequence, base := snapshotSequences(t, history.next(players))
+			if sequence != uint64(tt.sent+1) {
+				t.Errorf("got sequence %d, want %d", sequence, tt.sent+1)
+			}
+			if base != tt.wantBase {
+				t.Errorf("got base %d, want %d", base, tt.wantBase)
+			}
+		})
+	}
+}
+
+// 差量里相对于基准新增、变化和离开的玩家
+func TestSnapshotHistoryDelta(t *testing.T) {
+	tests := []struct {
+		name        string
+		base        []*packets.PlayerMessage
+		current     []*packets.PlayerMessage
+		wantAdded   []uint64
+		wantChanged []uint64
+		wantRemoved []uint64
+	}{
+		{
+			name:    "unchanged",
+			base:    []*packets.PlayerMessage{testPlayer(1, 0), testPlayer(2, 0)},
+			current: []*packets.PlayerMessage{testPlayer(1, 0), testPlayer(2, 0)},
+		},
+		{
+			name:      "added",
+			base:      []*packets.PlayerMessage{testPlayer(1, 0)},
+			current:   []*packets.PlayerMessage{testPlayer(1, 0), testPlayer(2, 0)},
+			wantAdded: []uint64{2},
+		},
+		{
+			name:        "changed",
+			base:        []*packets.PlayerMessage{testPlayer(1, 0), testPlayer(2, 0)},
+			current:     []*packets.PlayerMessage{testPlayer(1, 5), testPlayer(2, 0)},
+			wantChanged: []uint64{1},
+		},
+		{
+			name:        "removed",
+			base:        []*packets.PlayerMessage{testPlayer(3, 0), testPlayer(1, 0), testPlayer(2, 0)},
+			current:     []*packets.PlayerMessage{testPlayer(2, 0)},
+			wantRemoved: []uint64{1, 3},
+		},
+		{
+			name:        "everything at once",
+			base:        []*packets.PlayerMessage{testPlayer(1, 0), testPlayer(2, 0), testPlayer(3, 0)},
+			current:     []*packets.PlayerMessage{testPlayer(1, 1), testPlayer(3, 0), testPlayer(4, 0)},
+			wantAdded:   []uint64{4},
+			wantChanged: []uint64{1},
+			wantRemoved: []uint64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &snapshotHistory{deltas: true}
+			sequence, _ := snapshotSequences(t, history.next(tt.base))
+			history.ack(sequence)
+
+			msg, ok := history.next(tt.current).(*packets.Packet_SnapshotDelta)
+			if !ok {
+				t.Fatal("got a full snapshot, want a delta")
+			}
+			delta := msg.SnapshotDelta
+
+			var added, changed []uint64
+			for _, player := range delta.Added {
+				added = append(added, player.Id)
+			}
+			for _, player := range delta.Changed {
+				changed = append(changed, player.Id)
+			}
+			if !slices.Equal(added, tt.wantAdded) {
+				t.Errorf("added %v, want %v", added, tt.wantAdded)
+			}
+			if !slices.Equal(changed, tt.wantChanged) {
+				t.Errorf("changed %v, want %v", changed, tt.wantChanged)
+			}
+			if !slices.Equal(delta.Removed, tt.wantRemoved) {
+				t.Errorf("removed %v, want %v", delta.Removed, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import "sync"
+
+// 一个观战的客户端看的房间和跟随的玩家，FollowId 为 0 时跟着房间里最大的玩家
+type Spectator struct {
+	RoomId   uint64
+	FollowId uint64
+}
+
+// A thread-safe set of the clients in the Spectating state. Spectators receive the same
+// updates as players in the room they watch, centred on the player they follow, but they
+// have no player of their own and do not take a place in the room.
+type Spectators struct {
+	byClient map[uint64]Spectator
+	mux      sync.Mutex
+}
+
+func NewSpectators() *Spectators {
+	return &Spectators{
+		byClient: make(map[uint64]Spectator),
+	}
+}
+
+// 开始观战，已经在观战时换成新的房间和玩家
+func (s *Spectators) Watch(clientId uint64, spectator Spectator) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.byClient[clientId] = spectator
+}
+
+func (s *Spectators) Stop(clientId uint64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.byClient, clientId)
+}
+
+func (s *Spectators) Get(clientId uint64) (Spectator, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	spectator, exists := s.byClient[clientId]
+	return spectator, exists
+}
+
+// 客户端所在或者正在观看的房间
+func (h *Hub) watchedRoom(clientId uint64) (*Room, bool) {
+	if room, exists := h.Rooms.RoomOf(clientId); exists {
+		return room, true
+	}
+	if spectator, exists := h.Spectators.Get(clientId); exists {
+		return h.Rooms.Get(spectator.RoomId)
+	}
+	return nil, false
+}
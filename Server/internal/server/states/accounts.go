@@ -0,0 +1,140 @@
+package states
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"server/internal/server/config"
+)
+
+// 长得像字母的数字，比较保留名字和屏蔽词之前先换掉，"adm1n" 和 "admin" 算同一个名字
+var lookalikes = strings.NewReplacer(
+	"0", "o",
+	"1", "l",
+	"i", "l",
+	"3", "e",
+	"4", "a",
+	"5", "s",
+	"7", "t",
+	"8", "b",
+	"_", "",
+	"-", "",
+)
+
+// 编译过的用户名规则。规则来自配置，测试里每个 Hub 可能不一样，所以按规则的字符串缓存
+var usernamePatterns sync.Map
+
+func usernamePattern(pattern string) (*regexp.Regexp, error) {
+	if compiled, ok := usernamePatterns.Load(pattern); ok {
+		return compiled.(*regexp.Regexp), nil
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	usernamePatterns.Store(pattern, compiled)
+	return compiled, nil
+}
+
+// 常见的弱密码，长度和字符种类的检查挡不住它们
+var commonPasswords = []string{
+	"password1", "password123", "passw0rd", "qwerty123", "qwertyuiop", "1q2w3e4r", "iloveyou1",
+	"letmein1", "welcome1", "abc12345", "abcd1234", "admin123", "baseball1", "football1",
+}
+
+// user_names 表里每个账号的两种名字
+const (
+	nameKindUsername = "username"
+	nameKindNickname = "nickname"
+)
+
+func normalizeLookalikes(name string) string {
+	return lookalikes.Replace(strings.ToLower(name))
+}
+
+// 按配置检查用户名：长度、允许的字符、保留的名字和屏蔽词
+func validateUsername(policy config.AccountsConfig, username string) error {
+	length := utf8.RuneCountInString(username)
+	if length == 0 {
+		return errors.New("empty")
+	}
+	if length < policy.UsernameMinLength {
+		return fmt.Errorf("too short (at least %d characters)", policy.UsernameMinLength)
+	}
+	if length > policy.UsernameMaxLength {
+		return fmt.Errorf("too long (at most %d characters)", policy.UsernameMaxLength)
+	}
+	if username != strings.TrimSpace(username) {
+		return errors.New("leading or trailing whitespace")
+	}
+
+	pattern, err := usernamePattern(policy.UsernamePattern)
+	if err != nil {
+		return fmt.Errorf("invalid username pattern: %w", err)
+	}
+	if !pattern.MatchString(username) {
+		return errors.New("contains characters that are not allowed")
+	}
+
+	normalized := normalizeLookalikes(username)
+	for _, reserved := range policy.ReservedNames {
+		if normalized == normalizeLookalikes(reserved) {
+			return errors.New("reserved")
+		}
+	}
+	for _, word := range policy.BannedWords {
+		if word = normalizeLookalikes(word); word != "" && strings.Contains(normalized, word) {
+			return errors.New("not allowed")
+		}
+	}
+
+	return nil
+}
+
+// 注册和修改密码时检查密码强度
+func validatePassword(policy config.AccountsConfig, username string, password string) error {
+	if utf8.RuneCountInString(password) < policy.MinPasswordLength {
+		return fmt.Errorf("too short (at least %d characters)", policy.MinPasswordLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	if classes < policy.MinPasswordClasses {
+		return fmt.Errorf("must mix at least %d of lowercase letters, uppercase letters, digits and symbols", policy.MinPasswordClasses)
+	}
+
+	lowered := strings.ToLower(password)
+	if username != "" && strings.Contains(lowered, strings.ToLower(username)) {
+		return errors.New("must not contain the username")
+	}
+	if slices.Contains(commonPasswords, lowered) {
+		return errors.New("too common")
+	}
+
+	return nil
+}
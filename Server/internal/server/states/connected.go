@@ -2,12 +2,12 @@ package states
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log"
 	"server/internal/server"
 	"server/internal/server/db"
-	"server/internal/server/objects"
 	"server/pkg/packets"
 	"strings"
 
@@ -19,9 +19,14 @@ type Connected struct {
 
 	logger *log.Logger
 
-	queries *db.Queries
+	queries db.Querier
 
 	dbCtx context.Context
+
+	// 客户端发来兼容的 HelloMessage 之后才能登录或者注册
+	handshakeDone bool
+	// 发过 hello 的客户端，版本不兼容时也不能再当成不握手的旧客户端
+	helloReceived bool
 }
 
 func (c *Connected) Name() string {
@@ -63,10 +68,22 @@ func (c *Connected) HandlerMessage(senderId uint64, message packets.Msg) {
 	// }
 
 	switch message := message.(type) {
+	case *packets.Packet_Hello:
+		c.handleHello(senderId, message)
 	case *packets.Packet_LoginRequest:
 		c.handleLoginRequest(senderId, message)
 	case *packets.Packet_RegisterRequest:
 		c.handleRegisterRequest(senderId, message)
+	case *packets.Packet_ReconnectRequest:
+		c.handleReconnectRequest(senderId, message)
+	case *packets.Packet_LeaderboardRequest:
+		c.handleLeaderboardRequest(senderId, message)
+	case *packets.Packet_RoomListRequest:
+		c.handleRoomListRequest(senderId, message)
+	case *packets.Packet_ChangePasswordRequest:
+		c.handleChangePasswordRequest(senderId, message)
+	case *packets.Packet_DeleteAccountRequest:
+		c.handleDeleteAccountRequest(senderId, message)
 	}
 }
 
@@ -74,6 +91,51 @@ func (c *Connected) OnExit() {
 
 }
 
+// 握手：检查协议版本，协商可选功能
+func (c *Connected) handleHello(senderId uint64, message *packets.Packet_Hello) {
+	if senderId != c.client.Id() {
+		c.logger.Printf("Received hello message from another client (Id %d)", senderId)
+		return
+	}
+
+	c.helloReceived = true
+
+	version := message.Hello.ProtocolVersion
+	if version < packets.MinProtocolVersion || version > packets.ProtocolVersion {
+		reason := fmt.Sprintf("Incompatible protocol version %d (server supports %d to %d) - please update your client", version, packets.MinProtocolVersion, packets.ProtocolVersion)
+		c.logger.Println(reason)
+		c.client.SocketSend(packets.NewDenyResponse(reason))
+		return
+	}
+
+	features := packets.NegotiateFeatures(message.Hello.Features)
+	c.client.SetProtocol(version, features)
+	c.handshakeDone = true
+
+	c.logger.Printf("Handshake complete (protocol version %d, features %v)", version, features)
+	c.client.SocketSend(packets.NewHello(packets.ProtocolVersion, features))
+}
+
+// 旧的 Godot 客户端不握手，直接注册或者登录。还没有发过 hello 的连接按旧协议处理
+func (c *Connected) acceptLegacyClient() {
+	if c.handshakeDone || c.helloReceived {
+		return
+	}
+
+	c.logger.Printf("Client did not send hello, using legacy protocol version %d", packets.LegacyProtocolVersion)
+	c.client.SetProtocol(packets.LegacyProtocolVersion, nil)
+	c.handshakeDone = true
+}
+
+// 没有完成握手的客户端不能登录或者注册
+func (c *Connected) requireHandshake() bool {
+	if !c.handshakeDone {
+		c.logger.Println("Client has not completed the handshake")
+		c.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Handshake required - please update your client to protocol version %d", packets.ProtocolVersion)))
+	}
+	return c.handshakeDone
+}
+
 // 登录逻辑
 func (c *Connected) handleLoginRequest(senderId uint64, message *packets.Packet_LoginRequest) {
 	if senderId != c.client.Id() {
@@ -81,34 +143,188 @@ func (c *Connected) handleLoginRequest(senderId uint64, message *packets.Packet_
 		return
 	}
 
+	c.acceptLegacyClient()
+	if !c.requireHandshake() {
+		return
+	}
+
 	username := message.LoginRequest.Username
 
+	user, ok := c.authenticate(username, message.LoginRequest.Password)
+	if !ok {
+		return
+	}
+
+	// 同一个账号只能在一个连接上玩
+	kick := c.client.Config().Accounts.DuplicateLogin == "kick"
+	token, replaced, err := c.client.Sessions().Create(username, c.client.Id(), kick)
+	if errors.Is(err, server.ErrAlreadyLoggedIn) {
+		c.logger.Printf("User %s is already logged in on another connection", username)
+		c.client.SocketSend(packets.NewDenyResponse("Account is already logged in on another connection"))
+		return
+	}
+	if err != nil {
+		c.logger.Printf("Failed to create session for user %s: %v", username, err)
+		c.client.SocketSend(packets.NewDenyResponse("Error logging in (internal server error) - please try again later"))
+		return
+	}
+
+	if replaced != nil {
+		c.endSession(*replaced, "Logged in from another connection")
+	}
+
+	c.logger.Printf("User %s logged in successfully", username)
+	c.client.SocketSend(packets.NewOkResponse())
+	c.client.SocketSend(packets.NewSession(token))
+
+	// 旧客户端没有大厅，登录成功就开始游戏
+	if c.client.ProtocolVersion() == packets.LegacyProtocolVersion {
+		c.enterLegacyGame(user)
+		return
+	}
+
+	// 先进大厅，玩家自己决定什么时候排队
+	c.client.SetState(&Lobby{username: user.Username})
+}
+
+// 不排队，进入任意一个有空位的房间
+func (c *Connected) enterLegacyGame(user db.User) {
+	room, err := c.client.Rooms().JoinAny(c.client.Id())
+	if err != nil {
+		c.logger.Printf("Error joining a room for %s: %v", user.Username, err)
+		c.client.SocketSend(packets.NewDenyResponse("Error joining a room (internal server error) - please try again later"))
+		return
+	}
+	sendRoomJoined(c.client, room)
+
+	c.client.SetState(&InGame{
+		player:   newPlayer(user),
+		userId:   user.ID,
+		username: user.Username,
+	})
+}
+
+// 结束同一个账号的旧会话（新的登录顶掉了它，或者密码改了）：在线的连接收到 kick 后断开，
+// 断线等待重连的玩家直接从世界里删除
+func (c *Connected) endSession(old server.Session, reason string) {
+	if old.Online() {
+		c.logger.Printf("Kicking player %d, %s: %s", old.PlayerId, old.Username, reason)
+		c.client.PassToPeer(packets.NewKick(reason), old.PlayerId)
+		return
+	}
+
+	c.logger.Printf("Removing disconnected player %d, %s: %s", old.PlayerId, old.Username, reason)
+	c.client.Rooms().Leave(old.PlayerId)
+}
+
+// 检查用户名和密码，失败时已经回复了客户端。不区分用户不存在和密码错误。
+// 失败太多次的 IP 和用户名在执行 bcrypt 之前就被拒绝
+func (c *Connected) authenticate(username string, password string) (db.User, bool) {
+	limiter := c.client.RateLimiter()
+	if err := limiter.CheckLogin(c.client.RemoteIP(), username); err != nil {
+		c.logger.Printf("Rate limited login for %s from %q: %v", username, c.client.RemoteIP(), err)
+		c.client.SocketSend(packets.NewDenyResponse(err.Error()))
+		return db.User{}, false
+	}
+
 	genericFailMessage := packets.NewDenyResponse("Incorrect username or password")
 
 	user, err := c.queries.GetUserByUsername(c.dbCtx, strings.ToLower(username))
 	if err != nil {
 		c.logger.Printf("Error getting user %s: %v", username, err)
+		limiter.FailLogin(c.client.RemoteIP(), username)
 		c.client.SocketSend(genericFailMessage)
-		return
+		return db.User{}, false
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(message.LoginRequest.Password))
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
 	if err != nil {
 		c.logger.Printf("User entered wrong password: %s", username)
+		limiter.FailLogin(c.client.RemoteIP(), username)
 		c.client.SocketSend(genericFailMessage)
+		return db.User{}, false
+	}
+
+	limiter.SucceedLogin(username)
+	return user, true
+}
+
+// 断线重连：用会话令牌接回原来的玩家，保留 ID、位置和大小
+func (c *Connected) handleReconnectRequest(senderId uint64, message *packets.Packet_ReconnectRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Printf("Received reconnect message from another client (Id %d)", senderId)
 		return
 	}
 
-	c.logger.Printf("User %s logged in successfully", username)
+	if !c.requireHandshake() {
+		return
+	}
+
+	session, ok := c.client.Sessions().Reattach(message.ReconnectRequest.Token)
+	if !ok {
+		c.logger.Println("Reconnect failed: session expired or invalid")
+		c.client.SocketSend(packets.NewDenyResponse("Session expired or invalid - please log in again"))
+		return
+	}
+
+	c.client.Reattach(session.PlayerId)
+	c.client.SocketSend(packets.NewId(session.PlayerId))
 	c.client.SocketSend(packets.NewOkResponse())
 
+	c.logger.Printf("User %s reconnected to player %d", session.Username, session.PlayerId)
+
+	// 断线时在大厅或者在排队，没有房间，回到大厅
+	room, inRoom := c.client.Rooms().RoomOf(session.PlayerId)
+	if !inRoom {
+		c.client.SetState(&Lobby{username: session.Username})
+		return
+	}
+	sendRoomJoined(c.client, room)
+
+	// 找不到账号时不记录这次的统计
+	user := loadUser(c.client, c.logger, session.Username)
+
+	// 断线期间被吃掉的话就重新生成一个
+	player, exists := room.SharedGameObjects.Players.Get(session.PlayerId)
+	if !exists {
+		player = newPlayer(user)
+	}
+
 	c.client.SetState(&InGame{
-		player: &objects.Player{
-			Name: username,
-		},
+		player:   player,
+		userId:   user.ID,
+		username: session.Username,
 	})
 }
 
+// 登录前也可以查看排行榜
+func (c *Connected) handleLeaderboardRequest(senderId uint64, message *packets.Packet_LeaderboardRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Printf("Received leaderboard request from another client (Id %d)", senderId)
+		return
+	}
+
+	if !c.requireHandshake() {
+		return
+	}
+
+	sendLeaderboard(c.client, c.logger, message.LeaderboardRequest)
+}
+
+// 登录前也可以查看有哪些房间
+func (c *Connected) handleRoomListRequest(senderId uint64, _ *packets.Packet_RoomListRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Printf("Received room list request from another client (Id %d)", senderId)
+		return
+	}
+
+	if !c.requireHandshake() {
+		return
+	}
+
+	sendRoomList(c.client)
+}
+
 // 注册逻辑
 func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Packet_RegisterRequest) {
 	if senderId != c.client.Id() {
@@ -116,8 +332,21 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 		return
 	}
 
+	c.acceptLegacyClient()
+	if !c.requireHandshake() {
+		return
+	}
+
+	limiter := c.client.RateLimiter()
+	if err := limiter.CheckRegister(c.client.RemoteIP()); err != nil {
+		c.logger.Printf("Rate limited registration from %q: %v", c.client.RemoteIP(), err)
+		c.client.SocketSend(packets.NewDenyResponse(err.Error()))
+		return
+	}
+
+	policy := c.client.Config().Accounts
 	username := strings.ToLower(message.RegisterRequest.Username)
-	err := validateUsername(message.RegisterRequest.Username)
+	err := validateUsername(policy, message.RegisterRequest.Username)
 	if err != nil {
 		reason := fmt.Sprintf("Invalid username: %v", err)
 		c.logger.Println(reason)
@@ -125,6 +354,17 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 		return
 	}
 
+	err = validatePassword(policy, username, message.RegisterRequest.Password)
+	if err != nil {
+		reason := fmt.Sprintf("Weak password: %v", err)
+		c.logger.Printf("Rejected password for %s: %v", username, err)
+		c.client.SocketSend(packets.NewDenyResponse(reason))
+		return
+	}
+
+	// 用户名已经存在也算一次，免得用注册来试探用户名
+	limiter.RecordRegister(c.client.RemoteIP())
+
 	_, err = c.queries.GetUserByUsername(c.dbCtx, username)
 	if err == nil {
 		c.logger.Printf("User already exists: %s", username)
@@ -132,6 +372,18 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 		return
 	}
 
+	// 和昵称一样，用户名也不能和别的账号的名字长得一样
+	normalized := normalizeLookalikes(username)
+	if _, err := c.queries.GetNameOwner(c.dbCtx, normalized); err == nil {
+		c.logger.Printf("Username %s looks like another player's name", username)
+		c.client.SocketSend(packets.NewDenyResponse("Invalid username: looks like another player's name"))
+		return
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		c.logger.Printf("Failed to check name %s: %v", username, err)
+		c.client.SocketSend(packets.NewDenyResponse("Error registering user (internal server error) - please try again later"))
+		return
+	}
+
 	genericFailMessage := packets.NewDenyResponse("Error registering user (internal server error) - please try again later")
 
 	// Add new user
@@ -142,7 +394,7 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 		return
 	}
 
-	_, err = c.queries.CreateUser(c.dbCtx, db.CreateUserParams{
+	user, err := c.queries.CreateUser(c.dbCtx, db.CreateUserParams{
 		Username:     username,
 		PasswordHash: string(passwordHash),
 	})
@@ -153,21 +405,128 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 		return
 	}
 
+	// 同时注册的长得像的名字只有一个能占住，另一个的账号删掉
+	err = c.queries.SetUserName(c.dbCtx, db.SetUserNameParams{
+		NormalizedName: normalized,
+		UserID:         user.ID,
+		Kind:           nameKindUsername,
+	})
+	if err != nil {
+		c.logger.Printf("Failed to reserve name for user %s: %v", username, err)
+		if err := c.queries.DeleteUser(c.dbCtx, user.ID); err != nil {
+			c.logger.Printf("Failed to delete user %s: %v", username, err)
+		}
+		c.client.SocketSend(genericFailMessage)
+		return
+	}
+
 	c.client.SocketSend(packets.NewOkResponse())
 
 	c.logger.Printf("User %s registered successfully", username)
 }
 
-// 验证函数
-func validateUsername(username string) error {
-	if len(username) <= 0 {
-		return errors.New("empty")
+// 修改密码，需要当前的密码
+func (c *Connected) handleChangePasswordRequest(senderId uint64, message *packets.Packet_ChangePasswordRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Printf("Received change password message from another client (Id %d)", senderId)
+		return
 	}
-	if len(username) > 20 {
-		return errors.New("too long")
+
+	if !c.requireHandshake() {
+		return
 	}
-	if username != strings.TrimSpace(username) {
-		return errors.New("leading or trailing whitespace")
+
+	request := message.ChangePasswordRequest
+	user, ok := c.authenticate(request.Username, request.Password)
+	if !ok {
+		return
 	}
-	return nil
+
+	err := validatePassword(c.client.Config().Accounts, user.Username, request.NewPassword)
+	if err != nil {
+		reason := fmt.Sprintf("Weak password: %v", err)
+		c.logger.Printf("Rejected new password for %s: %v", user.Username, err)
+		c.client.SocketSend(packets.NewDenyResponse(reason))
+		return
+	}
+
+	genericFailMessage := packets.NewDenyResponse("Error changing password (internal server error) - please try again later")
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		c.logger.Printf("Failed to hash password: %s", user.Username)
+		c.client.SocketSend(genericFailMessage)
+		return
+	}
+
+	err = c.queries.UpdateUserPassword(c.dbCtx, db.UpdateUserPasswordParams{
+		PasswordHash: string(passwordHash),
+		ID:           user.ID,
+	})
+	if err != nil {
+		c.logger.Printf("Failed to change password for %s: %v", user.Username, err)
+		c.client.SocketSend(genericFailMessage)
+		return
+	}
+
+	// 旧密码登录的会话都失效：在线的连接被踢掉，断线的不能再用令牌重连
+	if old, exists := c.client.Sessions().Revoke(user.Username); exists {
+		c.endSession(old, "Password changed - please log in again")
+	}
+
+	c.client.SocketSend(packets.NewOkResponse())
+
+	c.logger.Printf("User %s changed their password", user.Username)
+}
+
+// 删除账号和它的统计数据。账号还在游戏里（或者等待重连）时不能删除
+func (c *Connected) handleDeleteAccountRequest(senderId uint64, message *packets.Packet_DeleteAccountRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Printf("Received delete account message from another client (Id %d)", senderId)
+		return
+	}
+
+	if !c.requireHandshake() {
+		return
+	}
+
+	request := message.DeleteAccountRequest
+	user, ok := c.authenticate(request.Username, request.Password)
+	if !ok {
+		return
+	}
+
+	if c.client.Sessions().HasUser(user.Username) {
+		c.logger.Printf("Refused to delete %s: the account is still in a game", user.Username)
+		c.client.SocketSend(packets.NewDenyResponse("Account is in use - leave the game before deleting it"))
+		return
+	}
+
+	// 统计、好友、名字和账号在一个事务里删除，失败时什么都不删，可以再试一次
+	err := c.client.DbTx().InTx(func(queries db.Querier) error {
+		deletes := []func() error{
+			func() error { return queries.DeleteDailyStats(c.dbCtx, user.ID) },
+			func() error { return queries.DeletePlayerStats(c.dbCtx, user.ID) },
+			func() error {
+				return queries.DeleteFriends(c.dbCtx, db.DeleteFriendsParams{UserID: user.ID, FriendID: user.ID})
+			},
+			func() error { return queries.DeleteUserNames(c.dbCtx, user.ID) },
+			func() error { return queries.DeleteUser(c.dbCtx, user.ID) },
+		}
+		for _, deleteRows := range deletes {
+			if err := deleteRows(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Printf("Failed to delete user %s: %v", user.Username, err)
+		c.client.SocketSend(packets.NewDenyResponse("Error deleting account (internal server error) - please try again later"))
+		return
+	}
+
+	c.client.SocketSend(packets.NewOkResponse())
+
+	c.logger.Printf("User %s deleted their account", user.Username)
 }
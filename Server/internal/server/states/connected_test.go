@@ -0,0 +1,503 @@
+package states_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"server/internal/server/config"
+	"server/pkg/packets"
+)
+
+func TestConnectedLoginAndRegistration(t *testing.T) {
+	// 每一步注入一个包，并检查它得到的回复：空的 wantDeny 表示 OkResponse
+	type step struct {
+		msg      packets.Msg
+		wantDeny string
+	}
+
+	tests := []struct {
+		name      string
+		handshake bool
+		steps     []step
+		// 最后一步之后应该进入游戏
+		wantInGame bool
+	}{
+		{
+			// 不握手的旧客户端也可以注册和登录，见 TestLegacyClient
+			name: "register without handshake",
+			steps: []step{
+				{msg: newRegisterRequest("alice", testPassword)},
+				{msg: newLoginRequest("alice", "wrong"), wantDeny: "Incorrect username or password"},
+			},
+		},
+		{
+			name: "incompatible protocol version",
+			steps: []step{
+				{msg: packets.NewHello(packets.MinProtocolVersion-1, nil), wantDeny: "Incompatible protocol version"},
+				{msg: newRegisterRequest("alice", testPassword), wantDeny: "Handshake required"},
+			},
+		},
+		{
+			name:      "register",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest("alice", testPassword)},
+			},
+		},
+		{
+			name:      "register with invalid username",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest(" alice", testPassword), wantDeny: "Invalid username"},
+				{msg: newRegisterRequest("", testPassword), wantDeny: "Invalid username"},
+				{msg: newRegisterRequest("al", testPassword), wantDeny: "too short"},
+				{msg: newRegisterRequest("alice🙂", testPassword), wantDeny: "not allowed"},
+				{msg: newRegisterRequest("al\tice", testPassword), wantDeny: "not allowed"},
+			},
+		},
+		{
+			name:      "register reserved username",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest("Admin", testPassword), wantDeny: "reserved"},
+				{msg: newRegisterRequest("adm1n", testPassword), wantDeny: "reserved"},
+				{msg: newRegisterRequest("SYS_TEM", testPassword), wantDeny: "reserved"},
+			},
+		},
+		{
+			name:      "register with weak password",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest("alice", "short1"), wantDeny: "too short"},
+				{msg: newRegisterRequest("alice", "onlyletters"), wantDeny: "must mix"},
+				{msg: newRegisterRequest("alice", "alice-2024"), wantDeny: "must not contain the username"},
+				{msg: newRegisterRequest("alice", "Password123"), wantDeny: "too common"},
+			},
+		},
+		{
+			name:      "change password",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest("alice", testPassword)},
+				{msg: newChangePasswordRequest("alice", "wrong", "battery-staple-7"), wantDeny: "Incorrect username or password"},
+				{msg: newChangePasswordRequest("alice", testPassword, "weak"), wantDeny: "Weak password"},
+				{msg: newChangePasswordRequest("Alice", testPassword, "battery-staple-7")},
+				{msg: newLoginRequest("alice", testPassword), wantDeny: "Incorrect username or password"},
+				{msg: newLoginRequest("alice", "battery-staple-7")},
+			},
+			wantInGame: true,
+		},
+		{
+			name:      "delete account",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest("alice", testPassword)},
+				{msg: newDeleteAccountRequest("alice", "wrong"), wantDeny: "Incorrect username or password"},
+				{msg: newDeleteAccountRequest("alice", testPassword)},
+				{msg: newLoginRequest("alice", testPassword), wantDeny: "Incorrect username or password"},
+				// 名字可以重新注册
+				{msg: newRegisterRequest("alice", testPassword)},
+			},
+		},
+		{
+			name:      "register existing user",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest("alice", testPassword)},
+				{msg: newRegisterRequest("Alice", testPassword), wantDeny: "User already exists"},
+				{msg: newRegisterRequest("AL1CE", testPassword), wantDeny: "looks like another player's name"},
+			},
+		},
+		{
+			name:      "login unknown user",
+			handshake: true,
+			steps: []step{
+				{msg: newLoginRequest("alice", testPassword), wantDeny: "Incorrect username or password"},
+			},
+		},
+		{
+			name:      "login with wrong password",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest("alice", testPassword)},
+				{msg: newLoginRequest("alice", "wrong"), wantDeny: "Incorrect username or password"},
+			},
+		},
+		{
+			name:      "login",
+			handshake: true,
+			steps: []step{
+				{msg: newRegisterRequest("alice", testPassword)},
+				{msg: newLoginRequest("alice", testPassword)},
+			},
+			wantInGame: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := newTestHub(t)
+			client := connect(t, hub)
+
+			if test.handshake {
+				handshake(t, client)
+			}
+
+			for i, step := range test.steps {
+				client.Inject(step.msg)
+
+				got, ok := client.WaitForCount(isResponse, i+1, waitTimeout)
+				if !ok {
+					t.Fatalf("step %d: no response to %T", i, step.msg)
+				}
+
+				switch response := got[i].Msg.(type) {
+				case *packets.Packet_OkResponse:
+					if step.wantDeny != "" {
+						t.Fatalf("step %d: got OkResponse, want deny %q", i, step.wantDeny)
+					}
+				case *packets.Packet_DenyResponse:
+					if step.wantDeny == "" || !strings.Contains(response.DenyResponse.Reason, step.wantDeny) {
+						t.Fatalf("step %d: got deny %q, want %q", i, response.DenyResponse.Reason, step.wantDeny)
+					}
+				}
+			}
+
+			if !test.wantInGame {
+				if count(client.Sent(), isOwnPlayer(client)) > 0 {
+					t.Fatal("client entered the game")
+				}
+				return
+			}
+
+			if _, ok := client.WaitFor(isMsg[*packets.Packet_Session], waitTimeout); !ok {
+				t.Fatal("client never received a session token")
+			}
+			enterLobby(t, client, "alice")
+
+			client.Inject(newQueueRequest("", ""))
+			if _, ok := client.WaitFor(isOwnPlayer(client), waitTimeout); !ok {
+				t.Fatal("client never received its player")
+			}
+			if !inWorld(hub, client.Id()) {
+				t.Fatal("player was not added to the world")
+			}
+		})
+	}
+}
+
+// Client/ 里的 Godot 客户端不握手：登录之后直接进入游戏，世界的状态只用 player 和 spore 消息发送
+func TestLegacyClient(t *testing.T) {
+	hub := newTestHub(t, func(cfg *config.Config) {
+		// 整个世界都在视野里，一定能看到 bob 和孢子
+		cfg.World.ViewRadius = 4 * cfg.World.SpawnBound
+		cfg.World.MaxSpores = 20
+	})
+	bob := joinGame(t, hub, "bob")
+
+	alice := connect(t, hub)
+	alice.Inject(newRegisterRequest("alice", testPassword))
+	alice.Inject(newLoginRequest("alice", testPassword))
+	if _, ok := alice.WaitFor(isOwnPlayer(alice), waitTimeout); !ok {
+		t.Fatalf("legacy client never entered the game: %v", responses(alice.Sent()))
+	}
+	if !inWorld(hub, alice.Id()) {
+		t.Fatal("legacy player was not added to the world")
+	}
+
+	// bob 的位置每次 tick 都单独发送
+	isBob := func(packet *packets.Packet) bool {
+		player, ok := packet.Msg.(*packets.Packet_Player)
+		return ok && player.Player.Id == bob.Id()
+	}
+	if _, ok := alice.WaitForCount(isBob, 3, waitTimeout); !ok {
+		t.Fatal("legacy client did not receive bob's player every tick")
+	}
+
+	sent := alice.Sent()
+	if n := count(sent, isMsg[*packets.Packet_Spore]); n == 0 {
+		t.Error("legacy client received no spores")
+	}
+	// 协议 1 里没有的消息和字段
+	unknown := map[string]func(*packets.Packet) bool{
+		"hello":         isMsg[*packets.Packet_Hello],
+		"session":       isMsg[*packets.Packet_Session],
+		"room joined":   isMsg[*packets.Packet_RoomJoined],
+		"profile":       isMsg[*packets.Packet_Profile],
+		"snapshots":     isSnapshot,
+		"spore batches": isMsg[*packets.Packet_SporesBatch],
+		"out of view":   isMsg[*packets.Packet_OutOfView],
+		// 协议 1 的 player 消息没有颜色
+		"colored players": func(packet *packets.Packet) bool {
+			player, ok := packet.Msg.(*packets.Packet_Player)
+			return ok && player.Player.Color != 0
+		},
+	}
+	for name, match := range unknown {
+		if n := count(sent, match); n > 0 {
+			t.Errorf("legacy client received %d %s", n, name)
+		}
+	}
+}
+
+func TestDeleteAccountInUse(t *testing.T) {
+	hub := newTestHub(t)
+	joinGame(t, hub, "alice")
+
+	client := connect(t, hub)
+	handshake(t, client)
+
+	client.Inject(newDeleteAccountRequest("ALICE", testPassword))
+
+	got, ok := client.WaitFor(isResponse, waitTimeout)
+	if !ok {
+		t.Fatal("no response to the delete request")
+	}
+	deny, isDeny := got.Msg.(*packets.Packet_DenyResponse)
+	if !isDeny || !strings.Contains(deny.DenyResponse.Reason, "Account is in use") {
+		t.Fatalf("got %v, want deny for an account in use", got.Msg)
+	}
+}
+
+func TestRegisterBannedWords(t *testing.T) {
+	hub := newTestHub(t, func(cfg *config.Config) {
+		cfg.Accounts.BannedWords = []string{"darn"}
+	})
+	client := connect(t, hub)
+	handshake(t, client)
+
+	names := []string{"darnit", "Xx_D4RN_xX", "darnation"}
+	for _, name := range names {
+		client.Inject(newRegisterRequest(name, testPassword))
+	}
+	client.Inject(newRegisterRequest("dawn", testPassword))
+
+	got, ok := client.WaitForCount(isResponse, len(names)+1, waitTimeout)
+	if !ok {
+		t.Fatal("not every register request got a response")
+	}
+
+	for i, name := range names {
+		deny, isDeny := got[i].Msg.(*packets.Packet_DenyResponse)
+		if !isDeny || !strings.Contains(deny.DenyResponse.Reason, "not allowed") {
+			t.Errorf("%s: got %v, want deny", name, got[i].Msg)
+		}
+	}
+	if _, isOk := got[len(names)].Msg.(*packets.Packet_OkResponse); !isOk {
+		t.Errorf("dawn: got %v, want OkResponse", got[len(names)].Msg)
+	}
+}
+
+func TestLoginRateLimit(t *testing.T) {
+	tests := []struct {
+		name            string
+		attempts        int
+		lockoutAttempts int
+		// 每次登录用的密码，最后一次是正确的密码
+		passwords []string
+		wantDeny  []string
+	}{
+		{
+			name:            "backoff",
+			attempts:        2,
+			lockoutAttempts: 10,
+			passwords:       []string{"wrong", "wrong", "wrong", testPassword},
+			wantDeny:        []string{"Incorrect", "Incorrect", "Incorrect", "Too many attempts"},
+		},
+		{
+			name:            "lockout",
+			attempts:        0,
+			lockoutAttempts: 1,
+			passwords:       []string{"wrong", testPassword},
+			wantDeny:        []string{"Incorrect", "locked out"},
+		},
+		{
+			name:            "success before the limit",
+			attempts:        2,
+			lockoutAttempts: 10,
+			passwords:       []string{"wrong", "wrong", testPassword},
+			wantDeny:        []string{"Incorrect", "Incorrect", ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := newTestHub(t, func(cfg *config.Config) {
+				cfg.RateLimit.LoginAttempts = test.attempts
+				cfg.RateLimit.LoginLockoutAttempts = test.lockoutAttempts
+				cfg.RateLimit.Backoff = config.Duration(time.Hour)
+				cfg.RateLimit.MaxBackoff = config.Duration(time.Hour)
+			})
+			client := connect(t, hub)
+			handshake(t, client)
+
+			client.Inject(newRegisterRequest("alice", testPassword))
+			for _, password := range test.passwords {
+				client.Inject(newLoginRequest("alice", password))
+			}
+
+			got, ok := client.WaitForCount(isResponse, 1+len(test.passwords), waitTimeout)
+			if !ok {
+				t.Fatalf("not every request got a response: %v", responses(client.Sent()))
+			}
+
+			for i, want := range test.wantDeny {
+				response := got[1+i].Msg
+				deny, isDeny := response.(*packets.Packet_DenyResponse)
+				if want == "" {
+					if isDeny {
+						t.Errorf("login %d: got deny %q, want OkResponse", i, deny.DenyResponse.Reason)
+					}
+					continue
+				}
+				if !isDeny || !strings.Contains(deny.DenyResponse.Reason, want) {
+					t.Errorf("login %d: got %v, want deny %q", i, response, want)
+				}
+			}
+		})
+	}
+}
+
+// 改密码之后旧的登录都失效：在线的连接被踢掉，断线的玩家被删除，令牌不能再重连
+func TestChangePasswordRevokesSessions(t *testing.T) {
+	for _, disconnectFirst := range []bool{false, true} {
+		name := "online"
+		if disconnectFirst {
+			name = "disconnected"
+		}
+		t.Run(name, func(t *testing.T) {
+			hub := newTestHub(t)
+			first := joinGame(t, hub, "alice")
+			firstId := first.Id()
+
+			sessionPacket, ok := first.WaitFor(isMsg[*packets.Packet_Session], waitTimeout)
+			if !ok {
+				t.Fatal("no session token")
+			}
+			token := sessionPacket.Msg.(*packets.Packet_Session).Session.Token
+
+			if disconnectFirst {
+				first.Disconnect()
+				deadline := time.Now().Add(waitTimeout)
+				for !hub.Sessions.IsDisconnected(firstId) && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+			}
+
+			second := connect(t, hub)
+			handshake(t, second)
+			if _, isOk := request(t, second, newChangePasswordRequest("alice", testPassword, "battery-staple-77")).(*packets.Packet_OkResponse); !isOk {
+				t.Fatal("changing the password was denied")
+			}
+
+			if !disconnectFirst {
+				if _, ok := first.WaitFor(isMsg[*packets.Packet_Kick], waitTimeout); !ok {
+					t.Error("the old connection was not kicked")
+				}
+			}
+
+			deadline := time.Now().Add(waitTimeout)
+			for inWorld(hub, firstId) {
+				if time.Now().After(deadline) {
+					t.Fatal("the old player is still in the world")
+				}
+				time.Sleep(time.Millisecond)
+			}
+			if hub.Sessions.HasUser("alice") {
+				t.Error("the old session survived the password change")
+			}
+
+			resumed := connect(t, hub)
+			handshake(t, resumed)
+			reconnect := &packets.Packet_ReconnectRequest{ReconnectRequest: &packets.ReconnectRequestMessage{Token: token}}
+			if _, isDeny := request(t, resumed, reconnect).(*packets.Packet_DenyResponse); !isDeny {
+				t.Error("the old token could still reconnect")
+			}
+		})
+	}
+}
+
+func TestDuplicateLogin(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		// 第一个连接在第二次登录之前断开
+		disconnectFirst bool
+		wantDeny        string
+		wantKick        bool
+	}{
+		{name: "kick", policy: "kick", wantKick: true},
+		{name: "reject", policy: "reject", wantDeny: "already logged in"},
+		{name: "reject replaces a disconnected player", policy: "reject", disconnectFirst: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := newTestHub(t, func(cfg *config.Config) {
+				cfg.Accounts.DuplicateLogin = test.policy
+			})
+			first := joinGame(t, hub, "alice")
+			firstId := first.Id()
+
+			if test.disconnectFirst {
+				first.Disconnect()
+				deadline := time.Now().Add(waitTimeout)
+				for !hub.Sessions.IsDisconnected(firstId) && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+			}
+
+			second := connect(t, hub)
+			handshake(t, second)
+			second.Inject(newLoginRequest("ALICE", testPassword))
+
+			response, ok := second.WaitFor(isResponse, waitTimeout)
+			if !ok {
+				t.Fatal("no response to the second login")
+			}
+
+			if test.wantDeny != "" {
+				deny, isDeny := response.Msg.(*packets.Packet_DenyResponse)
+				if !isDeny || !strings.Contains(deny.DenyResponse.Reason, test.wantDeny) {
+					t.Fatalf("got %v, want deny %q", response.Msg, test.wantDeny)
+				}
+				if !inWorld(hub, firstId) {
+					t.Error("the first player was removed from the world")
+				}
+				return
+			}
+
+			if _, isOk := response.Msg.(*packets.Packet_OkResponse); !isOk {
+				t.Fatalf("got %v, want OkResponse", response.Msg)
+			}
+			enterLobby(t, second, "alice")
+
+			second.Inject(newQueueRequest("", ""))
+			if _, ok := second.WaitFor(isOwnPlayer(second), waitTimeout); !ok {
+				t.Fatal("second connection never entered the game")
+			}
+
+			if test.wantKick {
+				if _, ok := first.WaitFor(isMsg[*packets.Packet_Kick], waitTimeout); !ok {
+					t.Error("first connection was not told it was kicked")
+				}
+			}
+
+			// 旧的玩家从世界里删除，只剩新的
+			deadline := time.Now().Add(waitTimeout)
+			for {
+				if !inWorld(hub, firstId) {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatal("the first player is still in the world")
+				}
+				time.Sleep(time.Millisecond)
+			}
+			if !hub.Sessions.HasUser("alice") {
+				t.Error("the new login has no session")
+			}
+		})
+	}
+}
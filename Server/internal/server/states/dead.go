@@ -0,0 +1,153 @@
+package states
+
+import (
+	"fmt"
+	"log"
+	"server/internal/server"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"time"
+)
+
+// 被吞并之后的死亡画面。玩家还占着房间的位置，但是世界里没有他的玩家，
+// 等 world.respawn_delay 或者客户端发 respawn_request 之后在同一个房间里重生
+type Dead struct {
+	client server.ClientInterfacer
+	logger *log.Logger
+
+	// 重生时沿用名字和颜色
+	player   *objects.Player
+	userId   int64
+	username string
+
+	respawnTimer *time.Timer
+	// 自动重生的时间。之前的死亡画面留下的计时器可能晚到，早于这个时间的不算
+	respawnAt time.Time
+}
+
+func (d *Dead) Name() string {
+	return "Dead"
+}
+
+func (d *Dead) SetClient(client server.ClientInterfacer) {
+	d.client = client
+	loggingPrefix := fmt.Sprintf("Client %d [%s]:", client.Id(), d.Name())
+
+	d.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+}
+
+func (d *Dead) OnEnter() {
+	delay := d.client.Config().World.RespawnDelay.Duration()
+	if delay <= 0 {
+		d.respawn()
+		return
+	}
+
+	// 到时间之后像 Hub 一样给自己发一个重生请求，和其他消息一样在客户端自己的协程里处理
+	d.logger.Printf("%s will respawn in %s", d.player.Name, delay)
+	d.respawnAt = time.Now().Add(delay)
+	d.respawnTimer = time.AfterFunc(delay, func() {
+		d.client.ProcessMessage(0, &packets.Packet_RespawnRequest{RespawnRequest: &packets.RespawnRequestMessage{}})
+	})
+}
+
+func (d *Dead) HandlerMessage(senderId uint64, message packets.Msg) {
+	switch message := message.(type) {
+	case *packets.Packet_RespawnRequest:
+		d.handleRespawnRequest(senderId, message)
+	case *packets.Packet_Chat:
+		d.handleChat(senderId, message)
+	case *packets.Packet_SpectateRequest:
+		d.handleSpectateRequest(senderId, message)
+	case *packets.Packet_LeaveGameRequest:
+		d.handleLeaveGameRequest(senderId, message)
+	case *packets.Packet_LeaderboardRequest:
+		d.handleLeaderboardRequest(senderId, message)
+	case *packets.Packet_RoomListRequest:
+		d.handleRoomListRequest(senderId, message)
+	}
+}
+
+func (d *Dead) OnExit() {
+	if d.respawnTimer != nil {
+		d.respawnTimer.Stop()
+	}
+}
+
+// 客户端提前重生，或者等待的时间到了
+func (d *Dead) handleRespawnRequest(senderId uint64, _ *packets.Packet_RespawnRequest) {
+	if senderId != d.client.Id() && senderId != 0 {
+		return
+	}
+	// Stop 之后计时器的请求可能已经在排队，来自之前的死亡画面
+	if senderId == 0 && time.Now().Before(d.respawnAt) {
+		d.logger.Println("Ignoring a respawn timer from an earlier death")
+		return
+	}
+	d.respawn()
+}
+
+// 死亡画面里还可以在房间里聊天
+func (d *Dead) handleChat(senderId uint64, message *packets.Packet_Chat) {
+	if senderId == d.client.Id() {
+		d.client.Broadcast(message)
+	} else {
+		d.client.SocketSendAs(message, senderId)
+	}
+}
+
+// 不重生，改成观战。没有指定房间和玩家时看自己所在的房间
+func (d *Dead) handleSpectateRequest(senderId uint64, message *packets.Packet_SpectateRequest) {
+	if senderId != d.client.Id() {
+		return
+	}
+
+	roomId := message.SpectateRequest.RoomId
+	if roomId == 0 && message.SpectateRequest.PlayerId == 0 {
+		if room, inRoom := d.client.Rooms().RoomOf(d.client.Id()); inRoom {
+			roomId = room.Id
+		}
+	}
+
+	room, ok := spectateRoom(d.client, roomId, message.SpectateRequest.PlayerId)
+	if !ok {
+		return
+	}
+
+	d.client.SocketSend(packets.NewOkResponse())
+	d.client.SetState(&Spectating{
+		username: d.username,
+		roomId:   room.Id,
+		followId: message.SpectateRequest.PlayerId,
+	})
+}
+
+func (d *Dead) handleLeaveGameRequest(senderId uint64, _ *packets.Packet_LeaveGameRequest) {
+	if senderId == d.client.Id() {
+		d.client.SetState(&Lobby{username: d.username})
+	}
+}
+
+func (d *Dead) handleLeaderboardRequest(senderId uint64, message *packets.Packet_LeaderboardRequest) {
+	if senderId == d.client.Id() {
+		sendLeaderboard(d.client, d.logger, message.LeaderboardRequest)
+	}
+}
+
+func (d *Dead) handleRoomListRequest(senderId uint64, _ *packets.Packet_RoomListRequest) {
+	if senderId == d.client.Id() {
+		sendRoomList(d.client)
+	}
+}
+
+func (d *Dead) respawn() {
+	d.logger.Printf("Respawning %s", d.player.Name)
+	d.client.SetState(&InGame{
+		player: &objects.Player{
+			Name:  d.player.Name,
+			Color: d.player.Color,
+		},
+		userId:   d.userId,
+		username: d.username,
+	})
+}
@@ -0,0 +1,140 @@
+package states_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"server/internal/server"
+	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/pkg/packets"
+)
+
+// 模拟 Hub 判定 victim 被 killer 吞并，返回发给 victim 的死亡信息
+func consume(t *testing.T, hub *server.Hub, victim *clients.LoopbackClient, killer *clients.LoopbackClient) *packets.DeathMessage {
+	t.Helper()
+
+	// Hub 会先把被吞并的玩家从世界里删掉，再通知客户端
+	roomObjects(t, hub, victim.Id()).Players.Remove(victim.Id())
+	victim.ProcessMessage(0, packets.NewPlayerConsumed(victim.Id(), killer.Id()))
+
+	death, ok := victim.WaitFor(isMsg[*packets.Packet_Death], waitTimeout)
+	if !ok {
+		t.Fatal("no death message")
+	}
+	return death.Msg.(*packets.Packet_Death).Death
+}
+
+func TestDead(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay time.Duration
+		// 死亡画面里客户端做的事，nil 表示等着自动重生
+		action      packets.Msg
+		wantRespawn bool
+	}{
+		{name: "respawn after the delay", delay: 100 * time.Millisecond, wantRespawn: true},
+		{
+			name:        "respawn request",
+			delay:       time.Minute,
+			action:      &packets.Packet_RespawnRequest{RespawnRequest: &packets.RespawnRequestMessage{}},
+			wantRespawn: true,
+		},
+		{
+			name:   "spectate",
+			delay:  time.Minute,
+			action: newSpectateRequest(0, 0),
+		},
+		{
+			name:   "leave",
+			delay:  time.Minute,
+			action: &packets.Packet_LeaveGameRequest{LeaveGameRequest: &packets.LeaveGameRequestMessage{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := newTestHub(t, func(cfg *config.Config) {
+				cfg.World.RespawnDelay = config.Duration(test.delay)
+			})
+			alice := joinGame(t, hub, "alice")
+			bob := joinGame(t, hub, "bob")
+			room, _ := hub.Rooms.RoomOf(alice.Id())
+
+			death := consume(t, hub, alice, bob)
+			radius := hub.Config.World.PlayerRadius
+			if death.KillerId != bob.Id() || death.KillerName != "bob" || death.FinalMass != math.Pi*radius*radius ||
+				death.RespawnDelayMs != uint64(test.delay.Milliseconds()) {
+				t.Errorf("got death %v", death)
+			}
+
+			// 死亡画面里还占着房间的位置，但是不在世界里
+			if inWorld(hub, alice.Id()) {
+				t.Error("alice is still in the world")
+			}
+			if current, inRoom := hub.Rooms.RoomOf(alice.Id()); !inRoom || current != room {
+				t.Error("alice lost her place in the room")
+			}
+
+			if test.action != nil {
+				alice.Inject(test.action)
+			}
+
+			switch test.action.(type) {
+			case *packets.Packet_SpectateRequest:
+				if watching := spectating(t, alice, 1); watching.RoomId != room.Id {
+					t.Errorf("got spectating %v, want room %d", watching, room.Id)
+				}
+			case *packets.Packet_LeaveGameRequest:
+				if _, ok := alice.WaitForCount(isMsg[*packets.Packet_Profile], 2, waitTimeout); !ok {
+					t.Fatal("alice did not return to the lobby")
+				}
+			}
+
+			if !test.wantRespawn {
+				if inWorld(hub, alice.Id()) || count(alice.Sent(), isOwnPlayer(alice)) != 1 {
+					t.Error("alice respawned")
+				}
+				return
+			}
+
+			respawned, ok := alice.WaitForCount(isOwnPlayer(alice), 2, waitTimeout)
+			if !ok {
+				t.Fatal("alice did not respawn")
+			}
+			if player := respawned[1].Msg.(*packets.Packet_Player).Player; player.Name != "alice" || player.Radius != radius {
+				t.Errorf("respawned as %q with radius %f", player.Name, player.Radius)
+			}
+			if !inWorld(hub, alice.Id()) {
+				t.Error("respawned player was not added to the world")
+			}
+		})
+	}
+}
+
+// 之前的死亡画面留下的计时器晚到时不能让玩家提前重生
+func TestStaleRespawnTimer(t *testing.T) {
+	hub := newTestHub(t, func(cfg *config.Config) {
+		cfg.World.RespawnDelay = config.Duration(time.Minute)
+	})
+	alice := joinGame(t, hub, "alice")
+	bob := joinGame(t, hub, "bob")
+	consume(t, hub, alice, bob)
+
+	alice.ProcessMessage(0, &packets.Packet_RespawnRequest{RespawnRequest: &packets.RespawnRequestMessage{}})
+
+	// 客户端按顺序处理消息，收到房间列表时上面的请求已经处理完了
+	alice.Inject(&packets.Packet_RoomListRequest{RoomListRequest: &packets.RoomListRequestMessage{}})
+	if _, ok := alice.WaitFor(isMsg[*packets.Packet_RoomList], waitTimeout); !ok {
+		t.Fatal("no room list")
+	}
+	if inWorld(hub, alice.Id()) || count(alice.Sent(), isOwnPlayer(alice)) != 1 {
+		t.Fatal("alice respawned before her delay")
+	}
+
+	alice.Inject(&packets.Packet_RespawnRequest{RespawnRequest: &packets.RespawnRequestMessage{}})
+	if _, ok := alice.WaitForCount(isOwnPlayer(alice), 2, waitTimeout); !ok {
+		t.Fatal("alice could not respawn herself")
+	}
+}
@@ -0,0 +1,230 @@
+package states_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"server/internal/server"
+	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/pkg/packets"
+)
+
+// 等待服务器回复的时间，bcrypt 比较慢，在 -race 下更慢
+const waitTimeout = 15 * time.Second
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// 每个测试一个独立的 Hub 和数据库，configure 可以修改默认的测试设置
+func newTestHub(t *testing.T, configure ...func(*config.Config)) *server.Hub {
+	t.Helper()
+
+	cfg := config.Default()
+	cfg.Database.Path = filepath.Join(t.TempDir(), "test.sqlite")
+	cfg.World.MaxSpores = 3
+	cfg.World.TickInterval = config.Duration(10 * time.Millisecond)
+	// 玩家几乎不动，测试里的位置不会过期
+	cfg.World.PlayerSpeed = 0.001
+	cfg.World.LeaderboardInterval = config.Duration(20 * time.Millisecond)
+	// 被吞并之后马上重生，要测死亡画面的测试自己设置
+	cfg.World.RespawnDelay = 0
+	// 登录之后马上匹配，所有人都在同一个房间里
+	cfg.Matchmaking.TargetSize = 1
+	cfg.Matchmaking.Interval = config.Duration(10 * time.Millisecond)
+	cfg.Rooms.MaxRooms = 1
+
+	for _, f := range configure {
+		f(cfg)
+	}
+
+	hub := server.NewHub(cfg)
+	go hub.Run()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+		defer cancel()
+
+		if err := hub.Shutdown(ctx, "test finished"); err != nil {
+			t.Errorf("shutting down hub: %v", err)
+		}
+	})
+
+	return hub
+}
+
+// 注册一个回环客户端，等到它收到自己的 ID
+func connect(t *testing.T, hub *server.Hub) *clients.LoopbackClient {
+	t.Helper()
+
+	client := clients.NewLoopbackClient(hub)
+	go hub.Serve(client)
+
+	if _, ok := client.WaitFor(isMsg[*packets.Packet_Id], waitTimeout); !ok {
+		t.Fatal("client never received its id")
+	}
+
+	return client
+}
+
+// 握手时请求服务器支持的所有可选功能
+func handshake(t *testing.T, client *clients.LoopbackClient) {
+	t.Helper()
+	handshakeWith(t, client, packets.SupportedFeatures)
+}
+
+func handshakeWith(t *testing.T, client *clients.LoopbackClient, features []string) {
+	t.Helper()
+
+	client.Inject(packets.NewHello(packets.ProtocolVersion, features))
+	if _, ok := client.WaitFor(isMsg[*packets.Packet_Hello], waitTimeout); !ok {
+		t.Fatal("client never received the hello reply")
+	}
+}
+
+// 登录之后进入大厅，等到收到自己的账号信息
+func enterLobby(t *testing.T, client *clients.LoopbackClient, username string) {
+	t.Helper()
+
+	if _, ok := client.WaitFor(isMsg[*packets.Packet_Profile], waitTimeout); !ok {
+		t.Fatalf("%s never entered the lobby: %v", username, responses(client.Sent()))
+	}
+}
+
+// 连接、握手、注册并登录，从大厅排队，返回进入游戏的客户端
+func joinGame(t *testing.T, hub *server.Hub, username string) *clients.LoopbackClient {
+	t.Helper()
+	return joinGameWith(t, hub, username, packets.SupportedFeatures)
+}
+
+// 和 joinGame 一样，握手时只请求 features 里的可选功能
+func joinGameWith(t *testing.T, hub *server.Hub, username string, features []string) *clients.LoopbackClient {
+	t.Helper()
+
+	client := connect(t, hub)
+	handshakeWith(t, client, features)
+
+	client.Inject(newRegisterRequest(username, testPassword))
+	client.Inject(newLoginRequest(username, testPassword))
+	enterLobby(t, client, username)
+
+	client.Inject(newQueueRequest("", ""))
+	if _, ok := client.WaitFor(isOwnPlayer(client), waitTimeout); !ok {
+		t.Fatalf("%s never entered the game: %v", username, responses(client.Sent()))
+	}
+
+	return client
+}
+
+// 满足默认密码强度要求的密码
+const testPassword = "correct-horse-42"
+
+func newRegisterRequest(username string, password string) packets.Msg {
+	return &packets.Packet_RegisterRequest{
+		RegisterRequest: &packets.RegisterRequestMessage{Username: username, Password: password},
+	}
+}
+
+func newLoginRequest(username string, password string) packets.Msg {
+	return &packets.Packet_LoginRequest{
+		LoginRequest: &packets.LoginRequestMessage{Username: username, Password: password},
+	}
+}
+
+func newQueueRequest(partyId string, region string) packets.Msg {
+	return &packets.Packet_QueueRequest{
+		QueueRequest: &packets.QueueRequestMessage{PartyId: partyId, Region: region},
+	}
+}
+
+func newChangePasswordRequest(username string, password string, newPassword string) packets.Msg {
+	return &packets.Packet_ChangePasswordRequest{
+		ChangePasswordRequest: &packets.ChangePasswordRequestMessage{Username: username, Password: password, NewPassword: newPassword},
+	}
+}
+
+func newDeleteAccountRequest(username string, password string) packets.Msg {
+	return &packets.Packet_DeleteAccountRequest{
+		DeleteAccountRequest: &packets.DeleteAccountRequestMessage{Username: username, Password: password},
+	}
+}
+
+func isMsg[T packets.Msg](packet *packets.Packet) bool {
+	_, ok := packet.Msg.(T)
+	return ok
+}
+
+// 登录和注册的结果：OkResponse 或者 DenyResponse
+func isResponse(packet *packets.Packet) bool {
+	return isMsg[*packets.Packet_OkResponse](packet) || isMsg[*packets.Packet_DenyResponse](packet)
+}
+
+func responses(sent []*packets.Packet) []packets.Msg {
+	var msgs []packets.Msg
+	for _, packet := range sent {
+		if isResponse(packet) {
+			msgs = append(msgs, packet.Msg)
+		}
+	}
+	return msgs
+}
+
+// 客户端自己的玩家信息
+func isOwnPlayer(client *clients.LoopbackClient) func(*packets.Packet) bool {
+	return func(packet *packets.Packet) bool {
+		player, ok := packet.Msg.(*packets.Packet_Player)
+		return ok && player.Player.Id == client.Id()
+	}
+}
+
+func count(sent []*packets.Packet, match func(*packets.Packet) bool) int {
+	n := 0
+	for _, packet := range sent {
+		if match(packet) {
+			n++
+		}
+	}
+	return n
+}
+
+func isSnapshot(packet *packets.Packet) bool {
+	return isMsg[*packets.Packet_Snapshot](packet) || isMsg[*packets.Packet_SnapshotDelta](packet)
+}
+
+// 等 Hub 再跑几次 tick，之前交给它的输入一定已经处理过了
+func waitForTicks(t *testing.T, client *clients.LoopbackClient, ticks int) {
+	t.Helper()
+
+	seen := count(client.Sent(), isSnapshot)
+	if _, ok := client.WaitForCount(isSnapshot, seen+ticks, waitTimeout); !ok {
+		t.Fatalf("client did not receive %d more snapshots", ticks)
+	}
+}
+
+// 玩家所在房间的游戏对象
+func roomObjects(t *testing.T, hub *server.Hub, playerId uint64) *server.SharedGameObjects {
+	t.Helper()
+
+	room, exists := hub.Rooms.RoomOf(playerId)
+	if !exists {
+		t.Fatalf("player %d is not in a room", playerId)
+	}
+	return room.SharedGameObjects
+}
+
+// 玩家是否在它所在房间的世界里
+func inWorld(hub *server.Hub, playerId uint64) bool {
+	room, exists := hub.Rooms.RoomOf(playerId)
+	if !exists {
+		return false
+	}
+	_, exists = room.SharedGameObjects.Players.Get(playerId)
+	return exists
+}
@@ -1,22 +1,31 @@
 package states
 
 import (
-	"context"
 	"fmt"
 	"log"
-	"math"
-	"math/rand/v2"
 	"server/internal/server"
+	"server/internal/server/db"
 	"server/internal/server/objects"
 	"server/pkg/packets"
 	"time"
 )
 
 type InGame struct {
-	client                 server.ClientInterfacer
-	player                 *objects.Player
-	logger                 *log.Logger
-	cancelPlayerUpdateLoop context.CancelFunc
+	client server.ClientInterfacer
+	player *objects.Player
+	logger *log.Logger
+
+	// 账号 ID，用来记录统计，0 表示不记录
+	userId int64
+	// 登录的用户名，回到大厅时用
+	username string
+
+	// 这一段游戏的统计，被吞并或者离开游戏时写入数据库
+	startedAt       time.Time
+	resumed         bool
+	sporesEaten     int64
+	playersConsumed int64
+	statsRecorded   bool
 }
 
 func (g *InGame) Name() string {
@@ -27,75 +36,96 @@ func (g *InGame) Name() string {
 
 func (g *InGame) SetClient(client server.ClientInterfacer) {
 	g.client = client
-	loggingPrefix := fmt.Sprintf("Client %d [%s] :", client.Id())
+	loggingPrefix := fmt.Sprintf("Client %d [%s] :", client.Id(), g.Name())
 
 	g.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
 }
 
 func (g *InGame) OnEnter() {
-	log.Printf("Adding player %s to the shared collection", g.player.Name)
+	g.startedAt = time.Now()
 
-	// Set the initial properties of the player
-	g.player.X, g.player.Y = objects.SpawnCoords(g.player.Radius, g.client.SharedGameObjects().Players, nil)
+	// 重连时玩家还在世界里，保留原来的位置和大小
+	if _, exists := g.client.SharedGameObjects().Players.Get(g.client.Id()); exists {
+		log.Printf("Player %s resumed", g.player.Name)
+		g.resumed = true
 
-	//共享的gameObjects 池子里面，添加玩家的player ID 和 客户端ID
-	//里面是加了线程锁的，所以添加游戏对象是在线程里面去添加游戏对象
-	go g.client.SharedGameObjects().Players.Add(g.player, g.client.Id())
+		// 玩家已经由 Hub 的 tick 循环移动，它的状态由 Hub 发给客户端，之后是视野里的所有东西
+		g.client.QueueInput(&packets.Packet_ReconnectRequest{ReconnectRequest: &packets.ReconnectRequestMessage{}})
+		return
+	}
+
+	log.Printf("Adding player %s to the shared collection", g.player.Name)
 
 	// 进入到了游戏后开始设置位置参数
-	g.player.X = rand.Float64() * 1000
-	g.player.Y = rand.Float64() * 1000
-	g.player.Speed = 150.0
-	g.player.Radius = 20.0
+	worldConfig := g.client.Config().World
+	g.player.Speed = worldConfig.PlayerSpeed
+	g.player.Radius = worldConfig.PlayerRadius
 
-	// Send the player's initial state to the client
+	// Set the initial properties of the player
+	g.player.X, g.player.Y = objects.SpawnCoords(g.player.Radius, worldConfig.SpawnBound, g.client.SharedGameObjects().Players, nil)
+
+	// 加入之后玩家只由 Hub 的 tick 循环修改，所以先把初始状态发给客户端，之后的大小从 Hub 的记录里取
 	g.client.SocketSend(packets.NewPlayer(g.client.Id(), g.player))
+	g.client.PeakRadii().Start(g.client.Id(), g.player.Radius)
 
-	// 进入到游戏后每个五秒钟就发送
-	go func() {
-		g.client.SharedGameObjects().Spores.ForEach(func(sporeId uint64, spore *objects.Spore) {
-			time.Sleep(5 * time.Millisecond)
-			g.client.SocketSend(packets.NewSpore(sporeId, spore))
-		})
-	}()
+	//共享的gameObjects 池子里面，添加玩家的player ID 和 客户端ID
+	g.client.SharedGameObjects().Players.Add(g.player, g.client.Id())
 }
 
 func (g *InGame) HandlerMessage(senderId uint64, message packets.Msg) {
 	switch message := message.(type) {
 	case *packets.Packet_Player:
 		g.handlePlayer(senderId, message)
+	case *packets.Packet_Snapshot:
+		g.handleSnapshot(senderId, message)
+	case *packets.Packet_SnapshotDelta:
+		g.handleSnapshotDelta(senderId, message)
+	case *packets.Packet_SnapshotAck:
+		g.handleSnapshotAck(senderId, message)
+	case *packets.Packet_OutOfView:
+		g.handleOutOfView(senderId, message)
 	case *packets.Packet_PlayerDirection:
 		g.handlePlayerDirection(senderId, message)
 	case *packets.Packet_Chat:
 		g.handleChat(senderId, message)
 	case *packets.Packet_SporeConsumed:
-		g.logger.Printf("Spore %d consumed by client %d", message.SporeConsumed.SporeId, senderId)
 		g.handleSporeConsumed(senderId, message) //处理孢子被吃的事件
 	case *packets.Packet_PlayerConsumed:
 		g.handlePlayerConsumed(senderId, message)
 	case *packets.Packet_Spore:
 		g.handleSpore(senderId, message)
+	case *packets.Packet_SporesBatch:
+		g.handleSporesBatch(senderId, message)
+	case *packets.Packet_LeaderboardRequest:
+		g.handleLeaderboardRequest(senderId, message)
+	case *packets.Packet_LiveLeaderboard:
+		g.handleLiveLeaderboard(senderId, message)
+	case *packets.Packet_RoomListRequest:
+		g.handleRoomListRequest(senderId, message)
+	case *packets.Packet_JoinRoomRequest:
+		g.handleJoinRoomRequest(senderId, message)
+	case *packets.Packet_LeaveGameRequest:
+		g.handleLeaveGameRequest(senderId, message)
 	}
 }
 
 func (g *InGame) OnExit() {
-	//游戏对象里头删除这个对象
-	if g.cancelPlayerUpdateLoop != nil {
-		g.cancelPlayerUpdateLoop()
+	// 被吞并时已经记录过了
+	if stats := g.finishStats(false); stats != nil {
+		g.saveStats(*stats)
 	}
-	g.client.SharedGameObjects().Players.Remove(g.client.Id())
-}
-
-func (g *InGame) syncPlayer(delta float64) {
-	newX := g.player.X + g.player.Speed*math.Cos(g.player.Direction)*delta
-	newY := g.player.Y + g.player.Speed*math.Sin(g.player.Direction)*delta
 
-	g.player.X = newX
-	g.player.Y = newY
+	// 断线的玩家留在世界里，等待重连或者会话过期
+	if g.client.Sessions().IsDisconnected(g.client.Id()) {
+		g.logger.Printf("Keeping player %s for reconnect", g.player.Name)
+		return
+	}
 
-	updatePacket := packets.NewPlayer(g.client.Id(), g.player)
-	g.client.Broadcast(updatePacket)
-	go g.client.SocketSend(updatePacket)
+	//游戏对象里头删除这个对象，换房间时已经从旧房间里删掉了
+	if shared := g.client.SharedGameObjects(); shared != nil {
+		shared.Players.Remove(g.client.Id())
+	}
+	g.client.PeakRadii().Remove(g.client.Id())
 }
 
 func (g *InGame) handlePlayer(senderId uint64, message *packets.Packet_Player) {
@@ -107,31 +137,44 @@ func (g *InGame) handlePlayer(senderId uint64, message *packets.Packet_Player) {
 	g.client.SocketSendAs(message, senderId)
 }
 
-func (g *InGame) playerUpdateLoop(ctx context.Context) {
-	const delta float64 = 0.05
-	ticker := time.NewTicker(time.Duration(delta*1000) * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			g.syncPlayer(delta)
-		case <-ctx.Done():
-			return
-		}
+// Hub 发来的完整快照
+func (g *InGame) handleSnapshot(senderId uint64, message *packets.Packet_Snapshot) {
+	if senderId == g.client.Id() {
+		g.logger.Println("Received snapshot message from our own client, ignoring")
+		return
 	}
+	g.client.SocketSendAs(message, senderId)
 }
 
-func (g *InGame) handlePlayerDirection(senderId uint64, message *packets.Packet_PlayerDirection) {
+// Hub 发来的相对于已确认快照的差量
+func (g *InGame) handleSnapshotDelta(senderId uint64, message *packets.Packet_SnapshotDelta) {
 	if senderId == g.client.Id() {
-		g.player.Direction = message.PlayerDirection.Direction
+		g.logger.Println("Received snapshot delta message from our own client, ignoring")
+		return
+	}
+	g.client.SocketSendAs(message, senderId)
+}
 
-		// If this is the first time receiving a player direction message from our client, start the player update loop
-		if g.cancelPlayerUpdateLoop == nil {
-			ctx, cancel := context.WithCancel(context.Background())
-			g.cancelPlayerUpdateLoop = cancel
-			go g.playerUpdateLoop(ctx)
-		}
+// 客户端确认收到快照，交给 Hub 作为下次差量的基准
+func (g *InGame) handleSnapshotAck(senderId uint64, message *packets.Packet_SnapshotAck) {
+	if senderId == g.client.Id() {
+		g.client.QueueInput(message)
+	}
+}
+
+// 离开视野的对象
+func (g *InGame) handleOutOfView(senderId uint64, message *packets.Packet_OutOfView) {
+	if senderId == g.client.Id() {
+		g.logger.Println("Received out of view message from our own client, ignoring")
+		return
+	}
+	g.client.SocketSendAs(message, senderId)
+}
+
+func (g *InGame) handlePlayerDirection(senderId uint64, message *packets.Packet_PlayerDirection) {
+	if senderId == g.client.Id() {
+		// 方向只交给 Hub，由 tick 循环推进位置
+		g.client.QueueInput(message)
 	}
 }
 
@@ -146,140 +189,206 @@ func (g *InGame) handleChat(senderId uint64, message *packets.Packet_Chat) {
 
 // 处理孢子被吃的事件
 func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_SporeConsumed) {
-	// We will implement this method in a moment
-	if senderId != g.client.Id() {
-		g.client.SocketSendAs(message, senderId)
+	// Consumption is detected by the hub on every tick, so claims from our own client are ignored
+	if senderId == g.client.Id() {
+		g.logger.Printf("Ignoring spore consumed claim for spore %d from our own client", message.SporeConsumed.SporeId)
 		return
 	}
 
-	// If the spore was supposedly consumed by our own player, we need to verify the plausibility of the event
-	errMsg := "Could not verify spore consumption: "
-
-	// First check if the spore exists
-	sporeId := message.SporeConsumed.SporeId
-	spore, err := g.getSpore(sporeId)
-	if err != nil {
-		g.logger.Println(errMsg + err.Error())
-		return
+	if message.SporeConsumed.PlayerId == g.client.Id() {
+		g.sporesEaten++
 	}
 
-	// Next, check if the spore is close enough to the player to be consumed
-	err = g.validatePlayerCloseToObject(spore.X, spore.Y, spore.Radius, 10)
-	if err != nil {
-		g.logger.Println(errMsg + err.Error())
+	// 以吃掉孢子的玩家作为发送者转发给前端
+	g.client.SocketSendAs(message, message.SporeConsumed.PlayerId)
+}
+
+// 吞并玩家
+func (g *InGame) handlePlayerConsumed(senderId uint64, message *packets.Packet_PlayerConsumed) {
+	// Consumption is detected by the hub on every tick, so claims from our own client are ignored
+	if senderId == g.client.Id() {
+		g.logger.Printf("Ignoring player consumed claim for player %d from our own client", message.PlayerConsumed.PlayerId)
 		return
 	}
 
-	// If we made it this far, the spore consumption is valid, so grow the player, remove the spore, and broadcast the event
-	sporeMass := radToMass(spore.Radius)
-	g.player.Radius = g.nextRadius(sporeMass)
+	g.client.SocketSendAs(message, message.PlayerConsumed.ConsumerId)
 
-	go g.client.SharedGameObjects().Spores.Remove(sporeId)
+	if message.PlayerConsumed.ConsumerId == g.client.Id() {
+		g.playersConsumed++
+	}
 
-	g.client.Broadcast(message)
-}
+	if message.PlayerConsumed.PlayerId == g.client.Id() {
+		// 在 Hub 的协程里，不能等数据库
+		if stats := g.finishStats(true); stats != nil {
+			go g.saveStats(*stats)
+		}
 
-// 判断孢子是否存在
-func (g *InGame) getSpore(sporeId uint64) (*objects.Spore, error) {
-	spore, exists := g.client.SharedGameObjects().Spores.Get(sporeId)
-	if !exists {
-		return nil, fmt.Errorf("spore with ID %d does not exist", sporeId)
+		g.handleDeath(message.PlayerConsumed.ConsumerId)
 	}
-	return spore, nil
 }
 
-// 判断孢子是否在附近
-func (g *InGame) validatePlayerCloseToObject(objX, objY, objRadius, buffer float64) error {
-	realDX := g.player.X - objX
-	realDY := g.player.Y - objY
-	realDistSq := realDX*realDX + realDY*realDY
+// 告诉客户端是谁吞并了自己，然后进入死亡画面，设置了被吞并后观战时跟着吞并自己的玩家看
+func (g *InGame) handleDeath(killerId uint64) {
+	killerName := ""
+	if shared := g.client.SharedGameObjects(); shared != nil {
+		if killer, exists := shared.Players.Get(killerId); exists {
+			killerName = killer.Name
+		}
+	}
 
-	thresholdDist := g.player.Radius + buffer + objRadius
-	thresholdDistSq := thresholdDist * thresholdDist
+	room, inRoom := g.client.Rooms().RoomOf(g.client.Id())
+	spectate := inRoom && g.client.Config().World.SpectateOnDeath
 
-	if realDistSq > thresholdDistSq {
-		return fmt.Errorf("player is too far from the object (distSq: %f, thresholdSq: %f)", realDistSq, thresholdDistSq)
+	respawnDelay := g.client.Config().World.RespawnDelay.Duration()
+	if spectate {
+		respawnDelay = 0
 	}
-	return nil
+	// 被吞并时的大小就是这一局最大的，玩家只会变大
+	radius, _ := g.client.PeakRadii().Get(g.client.Id())
+	g.client.SocketSend(packets.NewDeath(killerId, killerName, server.PlayerMass(radius), time.Since(g.startedAt), respawnDelay))
+
+	if spectate {
+		g.logger.Println("Player was consumed, spectating the consumer")
+		g.client.SetState(&Spectating{
+			username: g.username,
+			roomId:   room.Id,
+			followId: killerId,
+		})
+		return
+	}
+
+	log.Println("Player was consumed, waiting to respawn")
+	g.client.SetState(&Dead{
+		player:   g.player,
+		userId:   g.userId,
+		username: g.username,
+	})
 }
 
-// 计算圆的面积
-func radToMass(radius float64) float64 {
-	return math.Pi * radius * radius
+func (g *InGame) handleLeaderboardRequest(senderId uint64, message *packets.Packet_LeaderboardRequest) {
+	if senderId == g.client.Id() {
+		sendLeaderboard(g.client, g.logger, message.LeaderboardRequest)
+	}
 }
 
-func massToRad(mass float64) float64 {
-	return math.Sqrt(mass / math.Pi)
+// Hub 定时发来的实时排行榜
+func (g *InGame) handleLiveLeaderboard(senderId uint64, message *packets.Packet_LiveLeaderboard) {
+	if senderId == g.client.Id() {
+		g.logger.Println("Received live leaderboard message from our own client, ignoring")
+		return
+	}
+	g.client.SocketSendAs(message, senderId)
 }
 
-// 计算吃下孢子的体积
-func (g *InGame) nextRadius(massDiff float64) float64 {
-	oldMass := radToMass(g.player.Radius)
-	newMass := oldMass + massDiff
-	return massToRad(newMass)
+func (g *InGame) handleRoomListRequest(senderId uint64, _ *packets.Packet_RoomListRequest) {
+	if senderId == g.client.Id() {
+		sendRoomList(g.client)
+	}
 }
 
-// 吞并玩家
-func (g *InGame) handlePlayerConsumed(senderId uint64, message *packets.Packet_PlayerConsumed) {
+// 换到另一个房间，在新房间里重新生成玩家，这一局到此结束
+func (g *InGame) handleJoinRoomRequest(senderId uint64, message *packets.Packet_JoinRoomRequest) {
 	if senderId != g.client.Id() {
-		g.client.SocketSendAs(message, senderId)
-
-		if message.PlayerConsumed.PlayerId == g.client.Id() {
-			log.Println("Player was consumed, respawning")
-			g.client.SetState(&InGame{
-				player: &objects.Player{
-					Name: g.player.Name,
-				},
-			})
-		}
-
 		return
 	}
 
-	// If the other player was supposedly consumed by our own player, we need to verify the plausibility of the event
-	errMsg := "Could not verify player consumption: "
-
-	// First, check if the player exists
-	otherId := message.PlayerConsumed.PlayerId
-	other, err := g.getOtherPlayer(otherId)
-	if err != nil {
-		g.logger.Println(errMsg + err.Error())
+	room, ok := joinRoom(g.client, g.logger, message.JoinRoomRequest.RoomId)
+	if !ok {
 		return
 	}
+	g.logger.Printf("Player %s moved to room %d", g.player.Name, room.Id)
+
+	g.client.SetState(&InGame{
+		player: &objects.Player{
+			Name:  g.player.Name,
+			Color: g.player.Color,
+		},
+		userId:   g.userId,
+		username: g.username,
+	})
+}
 
-	// Next, check the other player's mass is smaller than our player's
-	ourMass := radToMass(g.player.Radius)
-	otherMass := radToMass(other.Radius)
-	if ourMass <= otherMass*1.5 {
-		g.logger.Printf(errMsg+"player not massive enough to consume the other player (our radius: %f, other radius: %f)", g.player.Radius, other.Radius)
+// 离开这一局回到大厅，房间的位置在进入大厅时让出来
+func (g *InGame) handleLeaveGameRequest(senderId uint64, _ *packets.Packet_LeaveGameRequest) {
+	if senderId != g.client.Id() {
 		return
 	}
 
-	// Finally, check if the player is close enough to the other to be consumed
-	err = g.validatePlayerCloseToObject(other.X, other.Y, other.Radius, 10)
-	if err != nil {
-		g.logger.Println(errMsg + err.Error())
-		return
+	g.logger.Printf("Player %s left the game", g.player.Name)
+	g.client.SetState(&Lobby{username: g.username})
+}
+
+// 结束这一段游戏的统计，已经记录过或者没有账号时返回 nil
+// 断线重连之后继续的游戏不再算作新的一局
+func (g *InGame) finishStats(died bool) *db.RecordPlayerStatsParams {
+	if g.statsRecorded || g.userId == 0 {
+		return nil
+	}
+	g.statsRecorded = true
+
+	// 玩家的字段只有 Hub 的协程能读，最大半径由 Hub 记录
+	peakRadius, _ := g.client.PeakRadii().Get(g.client.Id())
+	stats := &db.RecordPlayerStatsParams{
+		UserID:          g.userId,
+		PeakRadius:      peakRadius,
+		SporesEaten:     g.sporesEaten,
+		PlayersConsumed: g.playersConsumed,
+		TimeAliveMs:     time.Since(g.startedAt).Milliseconds(),
+	}
+	if !g.resumed {
+		stats.GamesPlayed = 1
 	}
+	if died {
+		stats.Deaths = 1
+	}
+	return stats
+}
 
-	// If we made it this far, the player consumption is valid, so grow the player, remove the consumed other, and broadcast the event
-	g.player.Radius = g.nextRadius(otherMass)
+// 写入累计和当天的统计
+func (g *InGame) saveStats(stats db.RecordPlayerStatsParams) {
+	queries := g.client.DbTx().Queries
+	ctx := g.client.DbTx().Ctx
 
-	go g.client.SharedGameObjects().Players.Remove(otherId)
+	if err := queries.RecordPlayerStats(ctx, stats); err != nil {
+		g.logger.Printf("Error recording stats for %s: %v", g.player.Name, err)
+		return
+	}
 
-	g.client.Broadcast(message)
-}
+	err := queries.RecordDailyStats(ctx, db.RecordDailyStatsParams{
+		UserID:          stats.UserID,
+		Day:             statsDay(time.Now()),
+		GamesPlayed:     stats.GamesPlayed,
+		PeakRadius:      stats.PeakRadius,
+		SporesEaten:     stats.SporesEaten,
+		PlayersConsumed: stats.PlayersConsumed,
+		TimeAliveMs:     stats.TimeAliveMs,
+		Deaths:          stats.Deaths,
+	})
+	if err != nil {
+		g.logger.Printf("Error recording daily stats for %s: %v", g.player.Name, err)
+	}
 
-// 判断是否有这个玩家
-func (g *InGame) getOtherPlayer(otherId uint64) (*objects.Player, error) {
-	other, exists := g.client.SharedGameObjects().Players.Get(otherId)
-	if !exists {
-		return nil, fmt.Errorf("player with ID %d does not exist", otherId)
+	// 匹配用的分数：吞并玩家加分，被吞并扣分
+	matchmaking := g.client.Config().Matchmaking
+	delta := stats.PlayersConsumed*int64(matchmaking.RatingPerKill) - stats.Deaths*int64(matchmaking.RatingPerDeath)
+	if delta == 0 {
+		return
+	}
+	if err := queries.AdjustUserRating(ctx, db.AdjustUserRatingParams{Delta: delta, ID: stats.UserID}); err != nil {
+		g.logger.Printf("Error adjusting rating for %s: %v", g.player.Name, err)
 	}
-	return other, nil
 }
 
 // 转发孢子的消息
 func (g *InGame) handleSpore(senderId uint64, message *packets.Packet_Spore) {
 	g.client.SocketSendAs(message, senderId)
 }
+
+// 转发批量的孢子
+func (g *InGame) handleSporesBatch(senderId uint64, message *packets.Packet_SporesBatch) {
+	if senderId == g.client.Id() {
+		g.logger.Println("Received spores batch message from our own client, ignoring")
+		return
+	}
+	g.client.SocketSendAs(message, senderId)
+}
@@ -0,0 +1,244 @@
+package states_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+)
+
+func TestInGameIgnoresConsumptionClaims(t *testing.T) {
+	hub := newTestHub(t)
+	alice := joinGame(t, hub, "alice")
+	bob := joinGame(t, hub, "bob")
+
+	// 找一个离 alice 足够远、不会被真的吃掉的孢子
+	playerPacket, _ := alice.WaitFor(isOwnPlayer(alice), waitTimeout)
+	player := playerPacket.Msg.(*packets.Packet_Player).Player
+
+	var sporeId uint64
+	found := false
+	roomObjects(t, hub, alice.Id()).Spores.ForEach(func(id uint64, spore *objects.Spore) {
+		if !found && math.Hypot(spore.X-player.X, spore.Y-player.Y) > 2*(player.Radius+spore.Radius) {
+			sporeId, found = id, true
+		}
+	})
+	if !found {
+		t.Fatal("no spore far enough from the player")
+	}
+
+	tests := []struct {
+		name  string
+		claim packets.Msg
+	}{
+		{
+			name:  "spore consumed",
+			claim: packets.NewSporeConsumed(sporeId, alice.Id()),
+		},
+		{
+			name:  "player consumed",
+			claim: packets.NewPlayerConsumed(bob.Id(), alice.Id()),
+		},
+		{
+			name:  "player consumed on behalf of another player",
+			claim: packets.NewPlayerConsumed(alice.Id(), bob.Id()),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			alice.Inject(test.claim)
+			waitForTicks(t, alice, 3)
+
+			if _, exists := roomObjects(t, hub, alice.Id()).Spores.Get(sporeId); !exists {
+				t.Error("claimed spore was removed")
+			}
+			for _, client := range []*clients.LoopbackClient{alice, bob} {
+				if !inWorld(hub, client.Id()) {
+					t.Errorf("player %d was removed", client.Id())
+				}
+			}
+
+			consumed := func(packet *packets.Packet) bool {
+				return isMsg[*packets.Packet_SporeConsumed](packet) || isMsg[*packets.Packet_PlayerConsumed](packet)
+			}
+			if n := count(alice.Sent(), consumed) + count(bob.Sent(), consumed); n > 0 {
+				t.Errorf("got %d consumed events, want none", n)
+			}
+			if n := count(alice.Sent(), isOwnPlayer(alice)); n != 1 {
+				t.Errorf("alice respawned (%d player packets)", n)
+			}
+		})
+	}
+}
+
+func TestInGameRespawn(t *testing.T) {
+	const otherId uint64 = 999
+
+	tests := []struct {
+		name string
+		// Hub 判定的吞并事件，id 是客户端自己的 ID
+		event       func(id uint64) packets.Msg
+		wantSender  func(id uint64) uint64
+		wantRespawn bool
+	}{
+		{
+			name:        "consumed by another player",
+			event:       func(id uint64) packets.Msg { return packets.NewPlayerConsumed(id, otherId) },
+			wantSender:  func(id uint64) uint64 { return otherId },
+			wantRespawn: true,
+		},
+		{
+			name:        "consumed another player",
+			event:       func(id uint64) packets.Msg { return packets.NewPlayerConsumed(otherId, id) },
+			wantSender:  func(id uint64) uint64 { return id },
+			wantRespawn: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := newTestHub(t)
+			client := joinGame(t, hub, "alice")
+			id := client.Id()
+
+			// Hub 会先把被吞并的玩家从世界里删掉，再通知客户端
+			if test.wantRespawn {
+				roomObjects(t, hub, id).Players.Remove(id)
+			}
+			client.ProcessMessage(0, test.event(id))
+
+			forwarded, ok := client.WaitFor(isMsg[*packets.Packet_PlayerConsumed], waitTimeout)
+			if !ok {
+				t.Fatal("consumed event was not forwarded to the client")
+			}
+			if want := test.wantSender(id); forwarded.SenderId != want {
+				t.Errorf("forwarded consumed event as sender %d, want %d", forwarded.SenderId, want)
+			}
+
+			if !test.wantRespawn {
+				waitForTicks(t, client, 3)
+				if n := count(client.Sent(), isOwnPlayer(client)); n != 1 {
+					t.Errorf("got %d player packets, want 1", n)
+				}
+				return
+			}
+
+			respawned, ok := client.WaitForCount(isOwnPlayer(client), 2, waitTimeout)
+			if !ok {
+				t.Fatal("client did not respawn")
+			}
+
+			player := respawned[1].Msg.(*packets.Packet_Player).Player
+			if player.Name != "alice" || player.Radius != hub.Config.World.PlayerRadius {
+				t.Errorf("respawned as %q with radius %f, want %q with radius %f", player.Name, player.Radius, "alice", hub.Config.World.PlayerRadius)
+			}
+			if !inWorld(hub, id) {
+				t.Error("respawned player was not added to the world")
+			}
+		})
+	}
+}
+
+// 断线重连之后接回原来的玩家，玩家的状态由 Hub 发出
+func TestInGameResume(t *testing.T) {
+	hub := newTestHub(t)
+	alice := joinGame(t, hub, "alice")
+	id := alice.Id()
+
+	sessionPacket, ok := alice.WaitFor(isMsg[*packets.Packet_Session], waitTimeout)
+	if !ok {
+		t.Fatal("no session token")
+	}
+	token := sessionPacket.Msg.(*packets.Packet_Session).Session.Token
+
+	alice.Disconnect()
+	deadline := time.Now().Add(waitTimeout)
+	for !hub.Sessions.IsDisconnected(id) {
+		if time.Now().After(deadline) {
+			t.Fatal("alice's session was never detached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	resumed := connect(t, hub)
+	handshake(t, resumed)
+	resumed.Inject(&packets.Packet_ReconnectRequest{ReconnectRequest: &packets.ReconnectRequestMessage{Token: token}})
+
+	playerPacket, ok := resumed.WaitFor(isOwnPlayer(resumed), waitTimeout)
+	if !ok {
+		t.Fatalf("resumed client never received its player: %v", responses(resumed.Sent()))
+	}
+	if resumed.Id() != id || playerPacket.Msg.(*packets.Packet_Player).Player.Name != "alice" {
+		t.Errorf("resumed as player %d (%v), want alice's player %d", resumed.Id(), playerPacket.Msg, id)
+	}
+	if !inWorld(hub, id) {
+		t.Error("alice's player left the world")
+	}
+
+	// 新的连接没有收到过旧连接的快照，第一个一定是完整的
+	first, ok := resumed.WaitFor(isSnapshot, waitTimeout)
+	if !ok {
+		t.Fatal("resumed client never received a snapshot")
+	}
+	if _, full := first.Msg.(*packets.Packet_Snapshot); !full {
+		t.Errorf("got %T as the first snapshot after resuming, want a full snapshot", first.Msg)
+	}
+}
+
+// 只发送客户端握手时协商过的消息，没有协商的功能换成旧的消息
+func TestInGameFeatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		features []string
+		// 确认快照之后会收到差量
+		wantDelta bool
+		// 孢子按批量发送
+		wantBatch bool
+	}{
+		{name: "all features", features: packets.SupportedFeatures, wantDelta: true, wantBatch: true},
+		{name: "no features", features: nil},
+		{name: "only batches", features: []string{packets.FeatureSporesBatch}, wantBatch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := newTestHub(t, func(cfg *config.Config) {
+				// 整个世界都在视野里，总有孢子会发给客户端
+				cfg.World.ViewRadius = 4 * cfg.World.SpawnBound
+				cfg.World.MaxSpores = 20
+			})
+			alice := joinGameWith(t, hub, "alice", tt.features)
+
+			first, ok := alice.WaitFor(isSnapshot, waitTimeout)
+			if !ok {
+				t.Fatal("alice never received a snapshot")
+			}
+			full, ok := first.Msg.(*packets.Packet_Snapshot)
+			if !ok {
+				t.Fatalf("got %T as the first snapshot, want a full snapshot", first.Msg)
+			}
+			alice.Inject(packets.NewSnapshotAck(full.Snapshot.Sequence))
+			waitForTicks(t, alice, 3)
+
+			sent := alice.Sent()
+			if got := count(sent, isMsg[*packets.Packet_SnapshotDelta]) > 0; got != tt.wantDelta {
+				t.Errorf("received snapshot deltas = %v, want %v", got, tt.wantDelta)
+			}
+			if got := count(sent, isMsg[*packets.Packet_SporesBatch]) > 0; got != tt.wantBatch {
+				t.Errorf("received spore batches = %v, want %v", got, tt.wantBatch)
+			}
+			if got := count(sent, isMsg[*packets.Packet_Spore]) > 0; got == tt.wantBatch {
+				t.Errorf("received single spores = %v, want %v", got, !tt.wantBatch)
+			}
+			if !slices.Contains(tt.features, packets.FeatureOutOfView) && count(sent, isMsg[*packets.Packet_OutOfView]) > 0 {
+				t.Error("received out of view without negotiating it")
+			}
+		})
+	}
+}
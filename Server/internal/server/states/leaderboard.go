@@ -0,0 +1,68 @@
+package states
+
+import (
+	"log"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/pkg/packets"
+	"time"
+)
+
+const (
+	// 客户端没有指定数量时返回的条数
+	defaultLeaderboardLimit = 10
+	// 一次最多返回的条数
+	maxLeaderboardLimit = 100
+)
+
+// 按天统计用的日期，统一用 UTC
+func statsDay(now time.Time) string {
+	return now.UTC().Format("2006-01-02")
+}
+
+// 查询历史或者当天的排行榜发给客户端，登录前后都可以请求
+func sendLeaderboard(client server.ClientInterfacer, logger *log.Logger, request *packets.LeaderboardRequestMessage) {
+	limit := int64(request.Limit)
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+	limit = min(limit, maxLeaderboardLimit)
+
+	queries := client.DbTx().Queries
+	ctx := client.DbTx().Ctx
+
+	var entries []*packets.LeaderboardEntryMessage
+	addEntry := func(username string, peakRadius float64, sporesEaten int64, playersConsumed int64) {
+		entries = append(entries, &packets.LeaderboardEntryMessage{
+			Rank:            uint32(len(entries) + 1),
+			Username:        username,
+			PeakRadius:      peakRadius,
+			SporesEaten:     uint64(sporesEaten),
+			PlayersConsumed: uint64(playersConsumed),
+		})
+	}
+
+	if request.Daily {
+		rows, err := queries.GetDailyLeaderboard(ctx, db.GetDailyLeaderboardParams{Day: statsDay(time.Now()), Limit: limit})
+		if err != nil {
+			logger.Printf("Error getting daily leaderboard: %v", err)
+			client.SocketSend(packets.NewDenyResponse("Error getting leaderboard (internal server error) - please try again later"))
+			return
+		}
+		for _, row := range rows {
+			addEntry(row.Username, row.PeakRadius, row.SporesEaten, row.PlayersConsumed)
+		}
+	} else {
+		rows, err := queries.GetLeaderboard(ctx, limit)
+		if err != nil {
+			logger.Printf("Error getting leaderboard: %v", err)
+			client.SocketSend(packets.NewDenyResponse("Error getting leaderboard (internal server error) - please try again later"))
+			return
+		}
+		for _, row := range rows {
+			addEntry(row.Username, row.PeakRadius, row.SporesEaten, row.PlayersConsumed)
+		}
+	}
+
+	client.SocketSend(packets.NewLeaderboard(request.Daily, entries))
+}
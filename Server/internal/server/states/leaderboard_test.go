@@ -0,0 +1,133 @@
+package states_test
+
+import (
+	"testing"
+	"time"
+
+	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/pkg/packets"
+)
+
+// 请求排行榜直到满足条件，统计是异步写入的
+func waitForLeaderboard(t *testing.T, client *clients.LoopbackClient, daily bool, ready func(*packets.LeaderboardMessage) bool) *packets.LeaderboardMessage {
+	t.Helper()
+
+	deadline := time.Now().Add(waitTimeout)
+	for requests := 1; time.Now().Before(deadline); requests++ {
+		client.Inject(&packets.Packet_LeaderboardRequest{
+			LeaderboardRequest: &packets.LeaderboardRequestMessage{Daily: daily},
+		})
+
+		got, ok := client.WaitForCount(isMsg[*packets.Packet_Leaderboard], requests, waitTimeout)
+		if !ok {
+			t.Fatal("no leaderboard response")
+		}
+
+		leaderboard := got[requests-1].Msg.(*packets.Packet_Leaderboard).Leaderboard
+		if ready(leaderboard) {
+			return leaderboard
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatal("leaderboard never contained the expected entries")
+	return nil
+}
+
+func TestLeaderboardRecordsStats(t *testing.T) {
+	tests := []struct {
+		name  string
+		daily bool
+	}{
+		{name: "all time", daily: false},
+		{name: "daily", daily: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := newTestHub(t)
+			alice := joinGame(t, hub, "alice")
+			viewer := connect(t, hub)
+			handshake(t, viewer)
+
+			// 还没有人结束过一局
+			empty := waitForLeaderboard(t, viewer, test.daily, func(*packets.LeaderboardMessage) bool { return true })
+			if len(empty.Entries) != 0 {
+				t.Fatalf("got %d entries before any game ended, want 0", len(empty.Entries))
+			}
+
+			// 模拟 Hub 判定 alice 吃了一个孢子和一个玩家，然后被吞并
+			id := alice.Id()
+			alice.ProcessMessage(0, packets.NewSporeConsumed(1000, id))
+			alice.ProcessMessage(0, packets.NewPlayerConsumed(1001, id))
+			roomObjects(t, hub, id).Players.Remove(id)
+			alice.ProcessMessage(0, packets.NewPlayerConsumed(id, 1001))
+
+			leaderboard := waitForLeaderboard(t, viewer, test.daily, func(l *packets.LeaderboardMessage) bool {
+				return len(l.Entries) > 0
+			})
+
+			if leaderboard.Daily != test.daily {
+				t.Errorf("got daily %v, want %v", leaderboard.Daily, test.daily)
+			}
+
+			entry := leaderboard.Entries[0]
+			if entry.Rank != 1 || entry.Username != "alice" || entry.SporesEaten != 1 || entry.PlayersConsumed != 1 {
+				t.Errorf("got entry %v, want rank 1 alice with 1 spore and 1 player", entry)
+			}
+			if entry.PeakRadius != hub.Config.World.PlayerRadius {
+				t.Errorf("got peak radius %f, want %f", entry.PeakRadius, hub.Config.World.PlayerRadius)
+			}
+		})
+	}
+}
+
+func TestLiveLeaderboard(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        int
+		wantEntries int
+	}{
+		{name: "everyone fits", size: 10, wantEntries: 2},
+		{name: "top only", size: 1, wantEntries: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := newTestHub(t, func(cfg *config.Config) {
+				cfg.World.LeaderboardSize = test.size
+			})
+			alice := joinGame(t, hub, "alice")
+			bob := joinGame(t, hub, "bob")
+
+			for _, client := range []*clients.LoopbackClient{alice, bob} {
+				isFull := func(packet *packets.Packet) bool {
+					live, ok := packet.Msg.(*packets.Packet_LiveLeaderboard)
+					return ok && live.LiveLeaderboard.PlayerCount == 2
+				}
+
+				packet, ok := client.WaitFor(isFull, waitTimeout)
+				if !ok {
+					t.Fatalf("client %d never received a leaderboard with both players", client.Id())
+				}
+				leaderboard := packet.Msg.(*packets.Packet_LiveLeaderboard).LiveLeaderboard
+
+				if len(leaderboard.Entries) != test.wantEntries {
+					t.Fatalf("got %d entries, want %d", len(leaderboard.Entries), test.wantEntries)
+				}
+				if leaderboard.OwnRank < 1 || leaderboard.OwnRank > 2 {
+					t.Errorf("got own rank %d, want 1 or 2", leaderboard.OwnRank)
+				}
+				if int(leaderboard.OwnRank) <= len(leaderboard.Entries) && leaderboard.Entries[leaderboard.OwnRank-1].PlayerId != client.Id() {
+					t.Errorf("entry at own rank %d is player %d, want %d", leaderboard.OwnRank, leaderboard.Entries[leaderboard.OwnRank-1].PlayerId, client.Id())
+				}
+				for i := 1; i < len(leaderboard.Entries); i++ {
+					if leaderboard.Entries[i].Mass > leaderboard.Entries[i-1].Mass {
+						t.Errorf("entries are not sorted by mass: %v", leaderboard.Entries)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,429 @@
+package states
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"strings"
+	"time"
+)
+
+// 颜色是 0xRRGGBB
+const maxColor = 0xFFFFFF
+
+// 登录之后的大厅：看好友、统计和排行榜，和大厅里的人聊天，设置昵称和颜色，
+// 准备好之后再排队或者直接进入房间
+type Lobby struct {
+	client server.ClientInterfacer
+	logger *log.Logger
+
+	queries db.Querier
+	dbCtx   context.Context
+
+	username string
+	// 进入大厅时从数据库读出来的账号，打完一局回来分数可能变了
+	user db.User
+}
+
+func (l *Lobby) Name() string {
+	return "Lobby"
+}
+
+func (l *Lobby) SetClient(client server.ClientInterfacer) {
+	l.client = client
+	loggingPrefix := fmt.Sprintf("Client %d [%s]:", client.Id(), l.Name())
+
+	l.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+
+	l.queries = client.DbTx().Queries
+	l.dbCtx = client.DbTx().Ctx
+}
+
+func (l *Lobby) OnEnter() {
+	// 大厅里的玩家不占房间的位置
+	l.client.Rooms().Leave(l.client.Id())
+	l.client.Lobby().Join(l.client.Id())
+
+	l.user = loadUser(l.client, l.logger, l.username)
+
+	l.logger.Printf("User %s entered the lobby", l.username)
+	l.sendProfile()
+	l.sendFriendList()
+}
+
+func (l *Lobby) HandlerMessage(senderId uint64, message packets.Msg) {
+	switch message := message.(type) {
+	case *packets.Packet_Chat:
+		l.handleChat(senderId, message)
+	case *packets.Packet_SetProfileRequest:
+		l.handleSetProfileRequest(senderId, message)
+	case *packets.Packet_FriendListRequest:
+		l.handleFriendListRequest(senderId, message)
+	case *packets.Packet_AddFriendRequest:
+		l.handleAddFriendRequest(senderId, message)
+	case *packets.Packet_RemoveFriendRequest:
+		l.handleRemoveFriendRequest(senderId, message)
+	case *packets.Packet_StatsRequest:
+		l.handleStatsRequest(senderId, message)
+	case *packets.Packet_LeaderboardRequest:
+		l.handleLeaderboardRequest(senderId, message)
+	case *packets.Packet_RoomListRequest:
+		l.handleRoomListRequest(senderId, message)
+	case *packets.Packet_QueueRequest:
+		l.handleQueueRequest(senderId, message)
+	case *packets.Packet_JoinRoomRequest:
+		l.handleJoinRoomRequest(senderId, message)
+	case *packets.Packet_SpectateRequest:
+		l.handleSpectateRequest(senderId, message)
+	case *packets.Packet_RoomJoined:
+		l.handleRoomJoined(senderId, message)
+	}
+}
+
+func (l *Lobby) OnExit() {
+	l.client.Lobby().Leave(l.client.Id())
+}
+
+// 大厅聊天，服务器填上发送者的昵称
+func (l *Lobby) handleChat(senderId uint64, message *packets.Packet_Chat) {
+	if senderId != l.client.Id() {
+		l.client.SocketSendAs(message, senderId)
+		return
+	}
+
+	if strings.TrimSpace(message.Chat.Msg) == "" {
+		return
+	}
+	l.client.Broadcast(packets.NewNamedChat(displayName(l.user), message.Chat.Msg))
+}
+
+// 昵称不能冒充别的账号的用户名或者昵称：和保留名字一样，比较时把长得像的字符当成一样。自己的名字可以用
+func (l *Lobby) nicknameTaken(nickname string) (bool, error) {
+	owner, err := l.queries.GetNameOwner(l.dbCtx, normalizeLookalikes(nickname))
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return owner != l.user.ID, nil
+}
+
+// 在 user_names 里占住新的昵称，让出原来的。空的昵称和像自己用户名的昵称不用占
+func (l *Lobby) reserveNickname(nickname string) error {
+	normalized := normalizeLookalikes(nickname)
+	if nickname == "" || normalized == normalizeLookalikes(l.username) {
+		return l.queries.DeleteUserName(l.dbCtx, db.DeleteUserNameParams{UserID: l.user.ID, Kind: nameKindNickname})
+	}
+	return l.queries.SetUserName(l.dbCtx, db.SetUserNameParams{
+		NormalizedName: normalized,
+		UserID:         l.user.ID,
+		Kind:           nameKindNickname,
+	})
+}
+
+// 设置昵称（空表示用用户名，规则和用户名一样）和颜色
+func (l *Lobby) handleSetProfileRequest(senderId uint64, message *packets.Packet_SetProfileRequest) {
+	if senderId != l.client.Id() || !l.requireAccount() {
+		return
+	}
+
+	nickname := message.SetProfileRequest.Nickname
+	color := message.SetProfileRequest.Color
+
+	if nickname != "" {
+		if err := validateUsername(l.client.Config().Accounts, nickname); err != nil {
+			reason := fmt.Sprintf("Invalid nickname: %v", err)
+			l.logger.Println(reason)
+			l.client.SocketSend(packets.NewDenyResponse(reason))
+			return
+		}
+
+		taken, err := l.nicknameTaken(nickname)
+		if err != nil {
+			l.logger.Printf("Error checking nickname %q: %v", nickname, err)
+			l.client.SocketSend(packets.NewDenyResponse("Error saving profile (internal server error) - please try again later"))
+			return
+		}
+		if taken {
+			l.logger.Printf("%s tried to use another account's name %q as a nickname", l.username, nickname)
+			l.client.SocketSend(packets.NewDenyResponse("Invalid nickname: looks like another player's name"))
+			return
+		}
+	}
+	if color > maxColor {
+		l.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Invalid color: must be at most 0x%06X", maxColor)))
+		return
+	}
+
+	// 检查之后别人也可能刚好占了这个名字，这时唯一索引让这里失败
+	if err := l.reserveNickname(nickname); err != nil {
+		l.logger.Printf("Error reserving nickname %q for %s: %v", nickname, l.username, err)
+		l.client.SocketSend(packets.NewDenyResponse("Error saving profile (internal server error) - please try again later"))
+		return
+	}
+
+	err := l.queries.UpdateUserProfile(l.dbCtx, db.UpdateUserProfileParams{
+		Nickname: nickname,
+		Color:    int64(color),
+		ID:       l.user.ID,
+	})
+	if err != nil {
+		l.logger.Printf("Error updating profile for %s: %v", l.username, err)
+		l.client.SocketSend(packets.NewDenyResponse("Error saving profile (internal server error) - please try again later"))
+		return
+	}
+
+	l.user.Nickname = nickname
+	l.user.Color = int64(color)
+	l.logger.Printf("User %s set nickname %q and color 0x%06X", l.username, nickname, color)
+
+	l.client.SocketSend(packets.NewOkResponse())
+	l.sendProfile()
+}
+
+func (l *Lobby) handleFriendListRequest(senderId uint64, _ *packets.Packet_FriendListRequest) {
+	if senderId == l.client.Id() {
+		l.sendFriendList()
+	}
+}
+
+func (l *Lobby) handleAddFriendRequest(senderId uint64, message *packets.Packet_AddFriendRequest) {
+	if senderId != l.client.Id() || !l.requireAccount() {
+		return
+	}
+
+	friend, ok := l.findFriend(message.AddFriendRequest.Username)
+	if !ok {
+		return
+	}
+	if friend.ID == l.user.ID {
+		l.client.SocketSend(packets.NewDenyResponse("You cannot add yourself as a friend"))
+		return
+	}
+
+	friends, err := l.queries.GetFriends(l.dbCtx, l.user.ID)
+	if err != nil {
+		l.logger.Printf("Error getting friends of %s: %v", l.username, err)
+		l.client.SocketSend(packets.NewDenyResponse("Error adding friend (internal server error) - please try again later"))
+		return
+	}
+	maxFriends := l.client.Config().Lobby.MaxFriends
+	if len(friends) >= maxFriends {
+		l.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Friend list is full (at most %d friends)", maxFriends)))
+		return
+	}
+
+	// 已经是好友的话什么都不做
+	if err := l.queries.AddFriend(l.dbCtx, db.AddFriendParams{UserID: l.user.ID, FriendID: friend.ID}); err != nil {
+		l.logger.Printf("Error adding friend %s for %s: %v", friend.Username, l.username, err)
+		l.client.SocketSend(packets.NewDenyResponse("Error adding friend (internal server error) - please try again later"))
+		return
+	}
+
+	l.client.SocketSend(packets.NewOkResponse())
+	l.sendFriendList()
+}
+
+func (l *Lobby) handleRemoveFriendRequest(senderId uint64, message *packets.Packet_RemoveFriendRequest) {
+	if senderId != l.client.Id() || !l.requireAccount() {
+		return
+	}
+
+	friend, ok := l.findFriend(message.RemoveFriendRequest.Username)
+	if !ok {
+		return
+	}
+
+	if err := l.queries.RemoveFriend(l.dbCtx, db.RemoveFriendParams{UserID: l.user.ID, FriendID: friend.ID}); err != nil {
+		l.logger.Printf("Error removing friend %s for %s: %v", friend.Username, l.username, err)
+		l.client.SocketSend(packets.NewDenyResponse("Error removing friend (internal server error) - please try again later"))
+		return
+	}
+
+	l.client.SocketSend(packets.NewOkResponse())
+	l.sendFriendList()
+}
+
+// 自己的累计统计，还没有玩过的话都是 0
+func (l *Lobby) handleStatsRequest(senderId uint64, _ *packets.Packet_StatsRequest) {
+	if senderId != l.client.Id() || !l.requireAccount() {
+		return
+	}
+
+	stats, err := l.queries.GetPlayerStats(l.dbCtx, l.user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		l.logger.Printf("Error getting stats for %s: %v", l.username, err)
+		l.client.SocketSend(packets.NewDenyResponse("Error getting stats (internal server error) - please try again later"))
+		return
+	}
+
+	l.client.SocketSend(packets.NewStats(
+		uint64(stats.GamesPlayed),
+		stats.PeakRadius,
+		uint64(stats.SporesEaten),
+		uint64(stats.PlayersConsumed),
+		time.Duration(stats.TimeAliveMs)*time.Millisecond,
+		uint64(stats.Deaths),
+	))
+}
+
+func (l *Lobby) handleLeaderboardRequest(senderId uint64, message *packets.Packet_LeaderboardRequest) {
+	if senderId == l.client.Id() {
+		sendLeaderboard(l.client, l.logger, message.LeaderboardRequest)
+	}
+}
+
+func (l *Lobby) handleRoomListRequest(senderId uint64, _ *packets.Packet_RoomListRequest) {
+	if senderId == l.client.Id() {
+		sendRoomList(l.client)
+	}
+}
+
+// 开始排队，queue_request 里的队伍和地区带进队列
+func (l *Lobby) handleQueueRequest(senderId uint64, message *packets.Packet_QueueRequest) {
+	if senderId != l.client.Id() {
+		return
+	}
+
+	l.client.SetState(&Matchmaking{
+		user:   l.user,
+		party:  message.QueueRequest.PartyId,
+		region: message.QueueRequest.Region,
+	})
+}
+
+// 不排队，直接进入指定的房间（比如好友所在的房间）
+func (l *Lobby) handleJoinRoomRequest(senderId uint64, message *packets.Packet_JoinRoomRequest) {
+	if senderId != l.client.Id() {
+		return
+	}
+
+	if _, ok := joinRoom(l.client, l.logger, message.JoinRoomRequest.RoomId); ok {
+		l.client.SetState(&InGame{
+			player:   newPlayer(l.user),
+			userId:   l.user.ID,
+			username: l.username,
+		})
+	}
+}
+
+// 不进入游戏，观看一个房间或者一个玩家
+func (l *Lobby) handleSpectateRequest(senderId uint64, message *packets.Packet_SpectateRequest) {
+	if senderId != l.client.Id() {
+		return
+	}
+
+	room, ok := spectateRoom(l.client, message.SpectateRequest.RoomId, message.SpectateRequest.PlayerId)
+	if !ok {
+		return
+	}
+
+	l.client.SocketSend(packets.NewOkResponse())
+	l.client.SetState(&Spectating{
+		username: l.username,
+		roomId:   room.Id,
+		followId: message.SpectateRequest.PlayerId,
+	})
+}
+
+// 刚离开队列的时候 Hub 可能已经把我们分进了房间，不进去，把位置让出来
+func (l *Lobby) handleRoomJoined(senderId uint64, _ *packets.Packet_RoomJoined) {
+	if senderId == l.client.Id() {
+		l.logger.Println("Received room joined message from our own client, ignoring")
+		return
+	}
+	l.client.Rooms().Leave(l.client.Id())
+}
+
+// 保存设置和好友需要账号 ID
+func (l *Lobby) requireAccount() bool {
+	if l.user.ID == 0 {
+		l.client.SocketSend(packets.NewDenyResponse("Account not found - please log in again"))
+		return false
+	}
+	return true
+}
+
+// 按用户名找好友的账号，找不到时已经回复了客户端
+func (l *Lobby) findFriend(username string) (db.User, bool) {
+	friend, err := l.queries.GetUserByUsername(l.dbCtx, strings.ToLower(username))
+	if errors.Is(err, sql.ErrNoRows) {
+		l.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("User %s does not exist", username)))
+		return db.User{}, false
+	}
+	if err != nil {
+		l.logger.Printf("Error getting user %s: %v", username, err)
+		l.client.SocketSend(packets.NewDenyResponse("Error finding user (internal server error) - please try again later"))
+		return db.User{}, false
+	}
+	return friend, true
+}
+
+func (l *Lobby) sendProfile() {
+	l.client.SocketSend(packets.NewProfile(l.user.Username, l.user.Nickname, uint32(l.user.Color), l.user.Rating))
+}
+
+// 好友列表，在线的好友带上所在的房间（0 表示在大厅或者在排队）
+func (l *Lobby) sendFriendList() {
+	if l.user.ID == 0 {
+		l.client.SocketSend(packets.NewFriendList(nil))
+		return
+	}
+
+	rows, err := l.queries.GetFriends(l.dbCtx, l.user.ID)
+	if err != nil {
+		l.logger.Printf("Error getting friends of %s: %v", l.username, err)
+		l.client.SocketSend(packets.NewDenyResponse("Error getting friends (internal server error) - please try again later"))
+		return
+	}
+
+	friends := make([]*packets.FriendMessage, 0, len(rows))
+	for _, row := range rows {
+		friend := &packets.FriendMessage{
+			Username: row.Username,
+			Nickname: row.Nickname,
+		}
+		if session, exists := l.client.Sessions().Find(row.Username); exists && session.Online() {
+			friend.Online = true
+			if room, inRoom := l.client.Rooms().RoomOf(session.PlayerId); inRoom {
+				friend.RoomId = room.Id
+			}
+		}
+		friends = append(friends, friend)
+	}
+
+	l.client.SocketSend(packets.NewFriendList(friends))
+}
+
+// 游戏里显示的名字：设置了昵称就用昵称
+func displayName(user db.User) string {
+	if user.Nickname != "" {
+		return user.Nickname
+	}
+	return user.Username
+}
+
+// 按账号的昵称和颜色生成新的玩家，位置和大小在进入 InGame 时设置
+func newPlayer(user db.User) *objects.Player {
+	return &objects.Player{
+		Name:  displayName(user),
+		Color: uint32(user.Color),
+	}
+}
+
+// 按用户名读账号，找不到时只有用户名：没有账号 ID 时不能保存设置和好友，也不记录统计
+func loadUser(client server.ClientInterfacer, logger *log.Logger, username string) db.User {
+	user, err := client.DbTx().Queries.GetUserByUsername(client.DbTx().Ctx, strings.ToLower(username))
+	if err != nil {
+		logger.Printf("Error getting user %s: %v", username, err)
+		return db.User{Username: username}
+	}
+	return user
+}
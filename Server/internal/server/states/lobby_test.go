@@ -0,0 +1,180 @@
+package states_test
+
+import (
+	"strings"
+	"testing"
+
+	"server/internal/server"
+	clients "server/internal/server/Clients"
+	"server/pkg/packets"
+)
+
+// 连接、握手、注册并登录，返回在大厅里的客户端
+func joinLobby(t *testing.T, hub *server.Hub, username string) *clients.LoopbackClient {
+	t.Helper()
+
+	client := connect(t, hub)
+	handshake(t, client)
+
+	client.Inject(newRegisterRequest(username, testPassword))
+	client.Inject(newLoginRequest(username, testPassword))
+	enterLobby(t, client, username)
+
+	return client
+}
+
+// 发一个请求，返回它的 OkResponse 或者 DenyResponse
+func request(t *testing.T, client *clients.LoopbackClient, msg packets.Msg) packets.Msg {
+	t.Helper()
+
+	seen := count(client.Sent(), isResponse)
+	client.Inject(msg)
+	got, ok := client.WaitForCount(isResponse, seen+1, waitTimeout)
+	if !ok {
+		t.Fatalf("no response to %v", msg)
+	}
+	return got[seen].Msg
+}
+
+// 客户端收到的第 n 个好友列表
+func friendList(t *testing.T, client *clients.LoopbackClient, n int) []*packets.FriendMessage {
+	t.Helper()
+
+	lists, ok := client.WaitForCount(isMsg[*packets.Packet_FriendList], n, waitTimeout)
+	if !ok {
+		t.Fatalf("client %d did not receive friend list %d", client.Id(), n)
+	}
+	return lists[n-1].Msg.(*packets.Packet_FriendList).FriendList.Friends
+}
+
+func newSetProfileRequest(nickname string, color uint32) packets.Msg {
+	return &packets.Packet_SetProfileRequest{
+		SetProfileRequest: &packets.SetProfileRequestMessage{Nickname: nickname, Color: color},
+	}
+}
+
+func newAddFriendRequest(username string) packets.Msg {
+	return &packets.Packet_AddFriendRequest{
+		AddFriendRequest: &packets.AddFriendRequestMessage{Username: username},
+	}
+}
+
+func TestLobby(t *testing.T) {
+	hub := newTestHub(t)
+	alice := joinLobby(t, hub, "alice")
+	bob := joinLobby(t, hub, "bob")
+	carol := joinGame(t, hub, "carol")
+
+	// 大厅里的玩家不占房间
+	if _, inRoom := hub.Rooms.RoomOf(alice.Id()); inRoom {
+		t.Fatal("alice is in a room while in the lobby")
+	}
+
+	profilePacket, _ := alice.WaitFor(isMsg[*packets.Packet_Profile], waitTimeout)
+	if profile := profilePacket.Msg.(*packets.Packet_Profile).Profile; profile.Username != "alice" || profile.Nickname != "" || profile.Rating != 1000 {
+		t.Errorf("got profile %v", profile)
+	}
+	if friends := friendList(t, alice, 1); len(friends) != 0 {
+		t.Errorf("got friends %v, want none", friends)
+	}
+
+	for _, test := range []struct {
+		name     string
+		msg      packets.Msg
+		wantDeny string
+	}{
+		{name: "reserved nickname", msg: newSetProfileRequest("adm1n", 0), wantDeny: "Invalid nickname"},
+		{name: "another player's username", msg: newSetProfileRequest("B0B", 0), wantDeny: "another player's name"},
+		{name: "invalid color", msg: newSetProfileRequest("", 0x1000000), wantDeny: "Invalid color"},
+		{name: "unknown friend", msg: newAddFriendRequest("nobody"), wantDeny: "does not exist"},
+		{name: "self as friend", msg: newAddFriendRequest("ALICE"), wantDeny: "yourself"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			deny, isDeny := request(t, alice, test.msg).(*packets.Packet_DenyResponse)
+			if !isDeny || !strings.Contains(deny.DenyResponse.Reason, test.wantDeny) {
+				t.Fatalf("got %v, want deny %q", deny, test.wantDeny)
+			}
+		})
+	}
+
+	if _, isOk := request(t, alice, newSetProfileRequest("Ali", 0x00FF00)).(*packets.Packet_OkResponse); !isOk {
+		t.Fatal("setting the profile was denied")
+	}
+	profiles, _ := alice.WaitForCount(isMsg[*packets.Packet_Profile], 2, waitTimeout)
+	if profile := profiles[1].Msg.(*packets.Packet_Profile).Profile; profile.Nickname != "Ali" || profile.Color != 0x00FF00 {
+		t.Errorf("got profile %v after setting it", profile)
+	}
+
+	// 别的账号的昵称也不能用；再设一次自己的昵称可以
+	if _, isOk := request(t, bob, newSetProfileRequest("Robert", 0)).(*packets.Packet_OkResponse); !isOk {
+		t.Fatal("bob could not set his nickname")
+	}
+	if deny, isDeny := request(t, alice, newSetProfileRequest("r0bert", 0)).(*packets.Packet_DenyResponse); !isDeny || !strings.Contains(deny.DenyResponse.Reason, "another player's name") {
+		t.Errorf("got %v, want deny for bob's nickname", deny)
+	}
+	if _, isOk := request(t, alice, newSetProfileRequest("Ali", 0x00FF00)).(*packets.Packet_OkResponse); !isOk {
+		t.Error("alice could not set her own nickname again")
+	}
+
+	// 在线的好友带上所在的房间，在大厅里是 0
+	request(t, alice, newAddFriendRequest("bob"))
+	request(t, alice, newAddFriendRequest("carol"))
+	friends := friendList(t, alice, 3)
+	if len(friends) != 2 || friends[0].Username != "bob" || !friends[0].Online || friends[0].RoomId != 0 ||
+		friends[1].Username != "carol" || !friends[1].Online || friends[1].RoomId == 0 {
+		t.Errorf("got friends %v", friends)
+	}
+
+	// 大厅聊天带上昵称，只发给大厅里的人
+	alice.Inject(packets.NewChat("anyone up for a game?"))
+	chat, ok := bob.WaitFor(isChatFrom(alice), waitTimeout)
+	if !ok {
+		t.Fatal("bob did not get alice's lobby chat")
+	}
+	if name := chat.Msg.(*packets.Packet_Chat).Chat.Name; name != "Ali" {
+		t.Errorf("got chat from %q, want Ali", name)
+	}
+	if count(carol.Sent(), isChatFrom(alice)) != 0 {
+		t.Error("carol got lobby chat in the arena")
+	}
+
+	// 进入游戏时用昵称和颜色，离开之后回到大厅
+	alice.Inject(newQueueRequest("", ""))
+	playerPacket, ok := alice.WaitFor(isOwnPlayer(alice), waitTimeout)
+	if !ok {
+		t.Fatal("alice never entered the game")
+	}
+	if player := playerPacket.Msg.(*packets.Packet_Player).Player; player.Name != "Ali" || player.Color != 0x00FF00 {
+		t.Errorf("got player %q with color %06X", player.Name, player.Color)
+	}
+
+	alice.Inject(&packets.Packet_LeaveGameRequest{LeaveGameRequest: &packets.LeaveGameRequestMessage{}})
+	if _, ok := alice.WaitForCount(isMsg[*packets.Packet_Profile], 4, waitTimeout); !ok {
+		t.Fatal("alice did not return to the lobby")
+	}
+	if _, inRoom := hub.Rooms.RoomOf(alice.Id()); inRoom {
+		t.Error("alice kept her room after leaving the game")
+	}
+
+	alice.Inject(&packets.Packet_StatsRequest{StatsRequest: &packets.StatsRequestMessage{}})
+	statsPacket, ok := alice.WaitFor(isMsg[*packets.Packet_Stats], waitTimeout)
+	if !ok {
+		t.Fatal("no stats")
+	}
+	if stats := statsPacket.Msg.(*packets.Packet_Stats).Stats; stats.GamesPlayed != 1 || stats.Deaths != 0 {
+		t.Errorf("got stats %v, want one game", stats)
+	}
+
+	// 自己的用户名（包括长得像的写法）可以当昵称
+	if _, isOk := request(t, alice, newSetProfileRequest("AL1CE", 0)).(*packets.Packet_OkResponse); !isOk {
+		t.Error("alice could not use her own username as a nickname")
+	}
+
+	remove := &packets.Packet_RemoveFriendRequest{RemoveFriendRequest: &packets.RemoveFriendRequestMessage{Username: "bob"}}
+	if _, isOk := request(t, alice, remove).(*packets.Packet_OkResponse); !isOk {
+		t.Fatal("removing bob was denied")
+	}
+	if friends := friendList(t, alice, 5); len(friends) != 1 || friends[0].Username != "carol" {
+		t.Errorf("got friends %v after removing bob", friends)
+	}
+}
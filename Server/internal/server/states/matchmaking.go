@@ -0,0 +1,163 @@
+package states
+
+import (
+	"fmt"
+	"log"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/pkg/packets"
+	"time"
+)
+
+// 在大厅里选择开始游戏之后排队，等 Hub 把分数接近的玩家分成一组放进房间
+type Matchmaking struct {
+	client server.ClientInterfacer
+	logger *log.Logger
+
+	// 大厅读出来的账号，分数、昵称和颜色都从这里取
+	user db.User
+
+	// 客户端用 queue_request 设置的队伍和地区
+	party  string
+	region string
+}
+
+func (m *Matchmaking) Name() string {
+	return "Matchmaking"
+}
+
+func (m *Matchmaking) SetClient(client server.ClientInterfacer) {
+	m.client = client
+	loggingPrefix := fmt.Sprintf("Client %d [%s]:", client.Id(), m.Name())
+
+	m.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+}
+
+func (m *Matchmaking) OnEnter() {
+	// 队伍满了就不排队，回到大厅
+	if !m.enqueue() {
+		m.client.SetState(&Lobby{username: m.user.Username})
+	}
+}
+
+func (m *Matchmaking) HandlerMessage(senderId uint64, message packets.Msg) {
+	switch message := message.(type) {
+	case *packets.Packet_QueueRequest:
+		m.handleQueueRequest(senderId, message)
+	case *packets.Packet_QueueStatus:
+		m.handleQueueStatus(senderId, message)
+	case *packets.Packet_RoomJoined:
+		m.handleRoomJoined(senderId, message)
+	case *packets.Packet_JoinRoomRequest:
+		m.handleJoinRoomRequest(senderId, message)
+	case *packets.Packet_RoomListRequest:
+		m.handleRoomListRequest(senderId, message)
+	case *packets.Packet_LeaderboardRequest:
+		m.handleLeaderboardRequest(senderId, message)
+	case *packets.Packet_LeaveGameRequest:
+		m.handleLeaveGameRequest(senderId, message)
+	}
+}
+
+func (m *Matchmaking) OnExit() {
+	m.client.Matchmaker().Remove(m.client.Id())
+}
+
+// 排队（已经在队列里时只更新队伍和地区），马上告诉客户端排在第几。队伍满了时拒绝客户端，返回 false
+func (m *Matchmaking) enqueue() bool {
+	matchmaker := m.client.Matchmaker()
+	err := matchmaker.Enqueue(server.MatchTicket{
+		PlayerId: m.client.Id(),
+		Rating:   m.user.Rating,
+		Party:    m.party,
+		Region:   m.region,
+		QueuedAt: time.Now(),
+	})
+	if err != nil {
+		m.logger.Printf("Could not queue %s in party %q: %v", m.user.Username, m.party, err)
+		m.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Party %q is full (at most %d players)", m.party, m.client.Config().Matchmaking.TargetSize)))
+		return false
+	}
+
+	m.logger.Printf("Queued %s (rating %d, party %q, region %q)", m.user.Username, m.user.Rating, m.party, m.region)
+	if status, queued := matchmaker.Status(m.client.Id(), time.Now()); queued {
+		m.client.SocketSend(status.Msg())
+	}
+	return true
+}
+
+func (m *Matchmaking) handleQueueRequest(senderId uint64, message *packets.Packet_QueueRequest) {
+	if senderId != m.client.Id() {
+		return
+	}
+
+	// 换到满了的队伍时还按原来的队伍排队
+	party, region := m.party, m.region
+	m.party = message.QueueRequest.PartyId
+	m.region = message.QueueRequest.Region
+	if !m.enqueue() {
+		m.party, m.region = party, region
+	}
+}
+
+// Hub 定时发来的队列状态
+func (m *Matchmaking) handleQueueStatus(senderId uint64, message *packets.Packet_QueueStatus) {
+	if senderId == m.client.Id() {
+		m.logger.Println("Received queue status message from our own client, ignoring")
+		return
+	}
+	m.client.SocketSendAs(message, senderId)
+}
+
+// Hub 已经把这一组放进了房间，进入游戏
+func (m *Matchmaking) handleRoomJoined(senderId uint64, message *packets.Packet_RoomJoined) {
+	if senderId == m.client.Id() {
+		m.logger.Println("Received room joined message from our own client, ignoring")
+		return
+	}
+
+	m.logger.Printf("Matched %s into room %d", m.user.Username, message.RoomJoined.Room.GetId())
+	m.client.SocketSendAs(message, senderId)
+	m.enterGame()
+}
+
+// 不排队，直接进入指定的房间
+func (m *Matchmaking) handleJoinRoomRequest(senderId uint64, message *packets.Packet_JoinRoomRequest) {
+	if senderId != m.client.Id() {
+		return
+	}
+
+	if _, ok := joinRoom(m.client, m.logger, message.JoinRoomRequest.RoomId); ok {
+		m.enterGame()
+	}
+}
+
+func (m *Matchmaking) handleRoomListRequest(senderId uint64, _ *packets.Packet_RoomListRequest) {
+	if senderId == m.client.Id() {
+		sendRoomList(m.client)
+	}
+}
+
+func (m *Matchmaking) handleLeaderboardRequest(senderId uint64, message *packets.Packet_LeaderboardRequest) {
+	if senderId == m.client.Id() {
+		sendLeaderboard(m.client, m.logger, message.LeaderboardRequest)
+	}
+}
+
+// 不排了，回到大厅
+func (m *Matchmaking) handleLeaveGameRequest(senderId uint64, _ *packets.Packet_LeaveGameRequest) {
+	if senderId != m.client.Id() {
+		return
+	}
+
+	m.logger.Printf("%s left the queue", m.user.Username)
+	m.client.SetState(&Lobby{username: m.user.Username})
+}
+
+func (m *Matchmaking) enterGame() {
+	m.client.SetState(&InGame{
+		player:   newPlayer(m.user),
+		userId:   m.user.ID,
+		username: m.user.Username,
+	})
+}
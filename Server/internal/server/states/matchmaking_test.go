@@ -0,0 +1,154 @@
+package states_test
+
+import (
+	"testing"
+	"time"
+
+	"server/internal/server"
+	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/internal/server/db"
+	"server/pkg/packets"
+)
+
+// 注册之后先改分数再登录，从大厅排队，返回排队中的客户端
+func queueWithRating(t *testing.T, hub *server.Hub, username string, delta int64) *clients.LoopbackClient {
+	t.Helper()
+
+	client := connect(t, hub)
+	handshake(t, client)
+
+	client.Inject(newRegisterRequest(username, testPassword))
+	if _, ok := client.WaitFor(isResponse, waitTimeout); !ok {
+		t.Fatalf("no response to registering %s", username)
+	}
+
+	dbTx := hub.NewDbTx()
+	user, err := dbTx.Queries.GetUserByUsername(dbTx.Ctx, username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dbTx.Queries.AdjustUserRating(dbTx.Ctx, db.AdjustUserRatingParams{Delta: delta, ID: user.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	client.Inject(newLoginRequest(username, testPassword))
+	enterLobby(t, client, username)
+
+	client.Inject(newQueueRequest("", ""))
+	if _, ok := client.WaitFor(isMsg[*packets.Packet_QueueStatus], waitTimeout); !ok {
+		t.Fatalf("%s was not queued: %v", username, responses(client.Sent()))
+	}
+	return client
+}
+
+func TestMatchmaking(t *testing.T) {
+	hub := newTestHub(t, func(cfg *config.Config) {
+		cfg.Matchmaking.TargetSize = 2
+		cfg.Rooms.MaxRooms = 4
+	})
+
+	alice := queueWithRating(t, hub, "alice", 0)
+	carol := queueWithRating(t, hub, "carol", 1000)
+
+	status, _ := carol.WaitFor(isMsg[*packets.Packet_QueueStatus], waitTimeout)
+	if queueStatus := status.Msg.(*packets.Packet_QueueStatus).QueueStatus; queueStatus.Rating != 2000 || queueStatus.Position != 2 || queueStatus.TargetSize != 2 {
+		t.Errorf("got carol's queue status %v", queueStatus)
+	}
+
+	bob := queueWithRating(t, hub, "bob", 50)
+	dave := queueWithRating(t, hub, "dave", 1050)
+
+	// 分数接近的两个人一组，各自一个房间
+	rooms := make(map[*clients.LoopbackClient]uint64)
+	for _, client := range []*clients.LoopbackClient{alice, bob, carol, dave} {
+		rooms[client] = joinedRoom(t, client, 1)
+		if _, ok := client.WaitFor(isOwnPlayer(client), waitTimeout); !ok {
+			t.Fatalf("client %d never entered the game", client.Id())
+		}
+	}
+	if rooms[alice] != rooms[bob] || rooms[carol] != rooms[dave] || rooms[alice] == rooms[carol] {
+		t.Errorf("got rooms alice %d, bob %d, carol %d, dave %d; want alice with bob and carol with dave",
+			rooms[alice], rooms[bob], rooms[carol], rooms[dave])
+	}
+}
+
+func TestRatingAfterDeath(t *testing.T) {
+	hub := newTestHub(t)
+	alice := joinGame(t, hub, "alice")
+
+	// 模拟 Hub 判定 alice 吞并了一个玩家，然后被吞并
+	id := alice.Id()
+	alice.ProcessMessage(0, packets.NewPlayerConsumed(1001, id))
+	alice.ProcessMessage(0, packets.NewPlayerConsumed(1002, id))
+	roomObjects(t, hub, id).Players.Remove(id)
+	alice.ProcessMessage(0, packets.NewPlayerConsumed(id, 1001))
+
+	// 统计在另一个协程里写入
+	want := int64(1000 + 2*hub.Config.Matchmaking.RatingPerKill - hub.Config.Matchmaking.RatingPerDeath)
+	dbTx := hub.NewDbTx()
+	var rating int64
+	for range 100 {
+		user, err := dbTx.Queries.GetUserByUsername(dbTx.Ctx, "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rating = user.Rating; rating == want {
+			return
+		}
+		waitForTicks(t, alice, 1)
+	}
+	t.Errorf("got rating %d, want %d", rating, want)
+}
+
+func TestPartySize(t *testing.T) {
+	// 没有房间，凑够人的队伍一直在排队
+	hub := newTestHub(t, func(cfg *config.Config) {
+		cfg.Matchmaking.TargetSize = 2
+		cfg.Rooms.Initial = 0
+		cfg.Rooms.MaxRooms = 0
+	})
+
+	// 客户端按顺序处理消息，收到房间列表时前面的 queue_request 已经处理完了
+	setParty := func(client *clients.LoopbackClient, party string) {
+		t.Helper()
+
+		client.Inject(newQueueRequest(party, ""))
+		client.Inject(&packets.Packet_RoomListRequest{RoomListRequest: &packets.RoomListRequestMessage{}})
+		if _, ok := client.WaitFor(isMsg[*packets.Packet_RoomList], waitTimeout); !ok {
+			t.Fatal("no room list")
+		}
+	}
+
+	alice := queueWithRating(t, hub, "alice", 0)
+	bob := queueWithRating(t, hub, "bob", 0)
+	setParty(alice, "friends")
+	setParty(bob, "friends")
+	if denied := count(alice.Sent(), isMsg[*packets.Packet_DenyResponse]) + count(bob.Sent(), isMsg[*packets.Packet_DenyResponse]); denied != 0 {
+		t.Fatalf("got %d denials for the first two party members", denied)
+	}
+
+	// 排队时换到满了的队伍被拒绝，还按原来的样子排队
+	carol := queueWithRating(t, hub, "carol", 0)
+	setParty(carol, "friends")
+	if _, ok := carol.WaitFor(isMsg[*packets.Packet_DenyResponse], waitTimeout); !ok {
+		t.Fatalf("carol joined a full party: %v", responses(carol.Sent()))
+	}
+	if _, queued := hub.Matchmaker.Status(carol.Id(), time.Now()); !queued {
+		t.Error("carol left the queue")
+	}
+
+	// 从大厅加入满了的队伍被拒绝，回到大厅
+	dave := connect(t, hub)
+	handshake(t, dave)
+	dave.Inject(newRegisterRequest("dave", testPassword))
+	dave.Inject(newLoginRequest("dave", testPassword))
+	enterLobby(t, dave, "dave")
+	dave.Inject(newQueueRequest("friends", ""))
+	if _, ok := dave.WaitFor(isMsg[*packets.Packet_DenyResponse], waitTimeout); !ok {
+		t.Fatalf("dave joined a full party: %v", responses(dave.Sent()))
+	}
+	if _, ok := dave.WaitForCount(isMsg[*packets.Packet_Profile], 2, waitTimeout); !ok {
+		t.Error("dave did not return to the lobby")
+	}
+}
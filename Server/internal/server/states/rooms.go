@@ -0,0 +1,64 @@
+package states
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+func newRoomInfoMessage(info server.RoomInfo) *packets.RoomInfoMessage {
+	return &packets.RoomInfoMessage{
+		Id:       info.Id,
+		Players:  uint32(info.Players),
+		Capacity: uint32(info.Capacity),
+	}
+}
+
+// 所有房间的人数和容量，登录前后都可以请求
+func sendRoomList(client server.ClientInterfacer) {
+	var current uint64
+	if room, exists := client.Rooms().RoomOf(client.Id()); exists {
+		current = room.Id
+	}
+
+	infos := client.Rooms().List()
+	rooms := make([]*packets.RoomInfoMessage, 0, len(infos))
+	for _, info := range infos {
+		rooms = append(rooms, newRoomInfoMessage(info))
+	}
+
+	client.SocketSend(packets.NewRoomList(rooms, current))
+}
+
+// 按 ID 进入房间，失败时已经回复了客户端。InGame 换房间，大厅和 Matchmaking 跳过排队都用它
+func joinRoom(client server.ClientInterfacer, logger *log.Logger, roomId uint64) (*server.Room, bool) {
+	if current, exists := client.Rooms().RoomOf(client.Id()); exists && current.Id == roomId {
+		client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Already in room %d", roomId)))
+		return nil, false
+	}
+
+	room, err := client.Rooms().Join(client.Id(), roomId)
+	switch {
+	case errors.Is(err, server.ErrRoomNotFound):
+		client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Room %d does not exist", roomId)))
+		return nil, false
+	case errors.Is(err, server.ErrRoomFull):
+		client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Room %d is full", roomId)))
+		return nil, false
+	case err != nil:
+		logger.Printf("Error joining room %d: %v", roomId, err)
+		client.SocketSend(packets.NewDenyResponse("Error joining room (internal server error) - please try again later"))
+		return nil, false
+	}
+
+	client.SocketSend(packets.NewOkResponse())
+	sendRoomJoined(client, room)
+	return room, true
+}
+
+// 进入游戏之前告诉客户端进了哪个房间
+func sendRoomJoined(client server.ClientInterfacer, room *server.Room) {
+	client.SocketSend(packets.NewRoomJoined(newRoomInfoMessage(client.Rooms().Info(room))))
+}
@@ -0,0 +1,144 @@
+package states_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/pkg/packets"
+)
+
+func newJoinRoomRequest(roomId uint64) packets.Msg {
+	return &packets.Packet_JoinRoomRequest{
+		JoinRoomRequest: &packets.JoinRoomRequestMessage{RoomId: roomId},
+	}
+}
+
+// 客户端收到的最后一个 RoomJoined 里的房间 ID
+func joinedRoom(t *testing.T, client *clients.LoopbackClient, n int) uint64 {
+	t.Helper()
+
+	joined, ok := client.WaitForCount(isMsg[*packets.Packet_RoomJoined], n, waitTimeout)
+	if !ok {
+		t.Fatalf("client %d was not told which room it joined", client.Id())
+	}
+	return joined[n-1].Msg.(*packets.Packet_RoomJoined).RoomJoined.Room.Id
+}
+
+// 是不是某个客户端发的聊天
+func isChatFrom(client *clients.LoopbackClient) func(*packets.Packet) bool {
+	return func(packet *packets.Packet) bool {
+		return isMsg[*packets.Packet_Chat](packet) && packet.SenderId == client.Id()
+	}
+}
+
+func TestRooms(t *testing.T) {
+	hub := newTestHub(t, func(cfg *config.Config) {
+		cfg.Rooms = config.RoomsConfig{Capacity: 2, Initial: 1, MaxRooms: 2}
+		// 断线的玩家马上让出房间的位置
+		cfg.Network.SessionGracePeriod = 0
+	})
+
+	alice := joinGame(t, hub, "alice")
+	bob := joinGame(t, hub, "bob")
+	carol := joinGame(t, hub, "carol")
+	dave := joinGame(t, hub, "dave")
+
+	// 匹配到的一组先放进空房间，没有空房间就新建，最多两个房间之后再挤进有空位的房间
+	for client, want := range map[*clients.LoopbackClient]uint64{alice: 1, bob: 2, carol: 1, dave: 2} {
+		if got := joinedRoom(t, client, 1); got != want {
+			t.Errorf("client %d joined room %d, want %d", client.Id(), got, want)
+		}
+	}
+
+	// 房间都满了，也不能再创建，只能一直排队
+	eve := connect(t, hub)
+	handshake(t, eve)
+	eve.Inject(newRegisterRequest("eve", testPassword))
+	eve.Inject(newLoginRequest("eve", testPassword))
+	enterLobby(t, eve, "eve")
+	eve.Inject(newQueueRequest("", ""))
+	if _, ok := eve.WaitForCount(isMsg[*packets.Packet_QueueStatus], 3, waitTimeout); !ok {
+		t.Fatal("eve is not queued")
+	}
+	if count(eve.Sent(), isMsg[*packets.Packet_RoomJoined]) != 0 {
+		t.Fatal("eve joined a room although all rooms are full")
+	}
+
+	// 排队时也能看房间列表
+	eve.Inject(&packets.Packet_RoomListRequest{RoomListRequest: &packets.RoomListRequestMessage{}})
+	listPacket, ok := eve.WaitFor(isMsg[*packets.Packet_RoomList], waitTimeout)
+	if !ok {
+		t.Fatal("no room list")
+	}
+	list := listPacket.Msg.(*packets.Packet_RoomList).RoomList
+	if len(list.Rooms) != 2 || list.Rooms[0].Players != 2 || list.Rooms[1].Players != 2 || list.CurrentRoomId != 0 {
+		t.Errorf("got room list %v", list)
+	}
+
+	eve.Disconnect()
+
+	// 聊天只发给同一个房间里的人
+	alice.Inject(packets.NewChat("hello room 1"))
+	if _, ok := carol.WaitFor(isChatFrom(alice), waitTimeout); !ok {
+		t.Error("carol did not get alice's chat")
+	}
+	waitForTicks(t, bob, 3)
+	if count(bob.Sent(), isChatFrom(alice)) != 0 {
+		t.Error("bob got a chat from another room")
+	}
+
+	tests := []struct {
+		name     string
+		roomId   uint64
+		wantDeny string
+	}{
+		{name: "full room", roomId: 2, wantDeny: "is full"},
+		{name: "missing room", roomId: 42, wantDeny: "does not exist"},
+		{name: "current room", roomId: 1, wantDeny: "Already in room"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seen := count(carol.Sent(), isMsg[*packets.Packet_DenyResponse])
+			carol.Inject(newJoinRoomRequest(test.roomId))
+
+			denies, ok := carol.WaitForCount(isMsg[*packets.Packet_DenyResponse], seen+1, waitTimeout)
+			if !ok {
+				t.Fatal("join was not denied")
+			}
+			if reason := denies[seen].Msg.(*packets.Packet_DenyResponse).DenyResponse.Reason; !strings.Contains(reason, test.wantDeny) {
+				t.Errorf("got deny %q, want %q", reason, test.wantDeny)
+			}
+		})
+	}
+
+	// dave 的会话过期之后房间 2 有了空位
+	dave.Disconnect()
+	deadline := time.Now().Add(waitTimeout)
+	for hub.Rooms.List()[1].Players != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("dave never left room 2")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// 换到有空位的房间，在新房间里重新生成
+	carol.Inject(newJoinRoomRequest(2))
+	if got := joinedRoom(t, carol, 2); got != 2 {
+		t.Fatalf("carol joined room %d, want 2", got)
+	}
+	if _, ok := carol.WaitForCount(isOwnPlayer(carol), 2, waitTimeout); !ok {
+		t.Fatal("carol was not respawned in the new room")
+	}
+	if room, _ := hub.Rooms.RoomOf(carol.Id()); room.Id != 2 || !inWorld(hub, carol.Id()) {
+		t.Error("carol is not in the world of room 2")
+	}
+
+	bob.Inject(packets.NewChat("hello room 2"))
+	if _, ok := carol.WaitFor(isChatFrom(bob), waitTimeout); !ok {
+		t.Error("carol did not get bob's chat after moving")
+	}
+}
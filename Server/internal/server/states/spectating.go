@@ -0,0 +1,236 @@
+package states
+
+import (
+	"fmt"
+	"log"
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+// 观战：和游戏中一样收到房间里视野内的更新，以跟随的玩家为中心（没有就是最大的玩家），
+// 但是没有自己的玩家，不占房间的位置，也不能操作
+type Spectating struct {
+	client server.ClientInterfacer
+	logger *log.Logger
+
+	// 登录的用户名，开始游戏或者回到大厅时用
+	username string
+
+	roomId uint64
+	// 跟随的玩家，0 表示跟着房间里最大的玩家
+	followId uint64
+}
+
+func (s *Spectating) Name() string {
+	return "Spectating"
+}
+
+func (s *Spectating) SetClient(client server.ClientInterfacer) {
+	s.client = client
+	loggingPrefix := fmt.Sprintf("Client %d [%s]:", client.Id(), s.Name())
+
+	s.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+}
+
+func (s *Spectating) OnEnter() {
+	// 被吞并之后观战的玩家让出房间的位置
+	s.client.Rooms().Leave(s.client.Id())
+	s.watch()
+}
+
+func (s *Spectating) HandlerMessage(senderId uint64, message packets.Msg) {
+	switch message := message.(type) {
+	case *packets.Packet_Player, *packets.Packet_Snapshot, *packets.Packet_SnapshotDelta, *packets.Packet_OutOfView,
+		*packets.Packet_Spore, *packets.Packet_SporesBatch, *packets.Packet_LiveLeaderboard:
+		s.handleWorldUpdate(senderId, message)
+	case *packets.Packet_SnapshotAck:
+		s.handleSnapshotAck(senderId, message)
+	case *packets.Packet_SporeConsumed:
+		s.handleSporeConsumed(senderId, message)
+	case *packets.Packet_PlayerConsumed:
+		s.handlePlayerConsumed(senderId, message)
+	case *packets.Packet_PlayerDirection:
+		s.handlePlayerDirection(senderId, message)
+	case *packets.Packet_Chat:
+		s.handleChat(senderId, message)
+	case *packets.Packet_SpectateRequest:
+		s.handleSpectateRequest(senderId, message)
+	case *packets.Packet_QueueRequest:
+		s.handleQueueRequest(senderId, message)
+	case *packets.Packet_JoinRoomRequest:
+		s.handleJoinRoomRequest(senderId, message)
+	case *packets.Packet_LeaveGameRequest:
+		s.handleLeaveGameRequest(senderId, message)
+	case *packets.Packet_RoomListRequest:
+		s.handleRoomListRequest(senderId, message)
+	case *packets.Packet_LeaderboardRequest:
+		s.handleLeaderboardRequest(senderId, message)
+	}
+}
+
+func (s *Spectating) OnExit() {
+	s.client.Spectators().Stop(s.client.Id())
+}
+
+// 告诉 Hub 看哪里，再告诉客户端
+func (s *Spectating) watch() {
+	s.client.Spectators().Watch(s.client.Id(), server.Spectator{RoomId: s.roomId, FollowId: s.followId})
+	s.logger.Printf("%s is spectating room %d, following player %d", s.username, s.roomId, s.followId)
+	s.client.SocketSend(packets.NewSpectating(s.roomId, s.followId))
+}
+
+// Hub 发来的世界更新和 InGame 一样原样转发
+func (s *Spectating) handleWorldUpdate(senderId uint64, message packets.Msg) {
+	if senderId == s.client.Id() {
+		s.logger.Printf("Received %T from our own client, ignoring", message)
+		return
+	}
+	s.client.SocketSendAs(message, senderId)
+}
+
+// 快照差量的基准还是要确认的
+func (s *Spectating) handleSnapshotAck(senderId uint64, message *packets.Packet_SnapshotAck) {
+	if senderId == s.client.Id() {
+		s.client.QueueInput(message)
+	}
+}
+
+func (s *Spectating) handleSporeConsumed(senderId uint64, message *packets.Packet_SporeConsumed) {
+	if senderId == s.client.Id() {
+		s.rejectInput()
+		return
+	}
+	s.client.SocketSendAs(message, message.SporeConsumed.PlayerId)
+}
+
+// 跟随的玩家被吞并了就改成跟着吞并它的玩家
+func (s *Spectating) handlePlayerConsumed(senderId uint64, message *packets.Packet_PlayerConsumed) {
+	if senderId == s.client.Id() {
+		s.rejectInput()
+		return
+	}
+	s.client.SocketSendAs(message, message.PlayerConsumed.ConsumerId)
+
+	if s.followId != 0 && message.PlayerConsumed.PlayerId == s.followId {
+		s.followId = message.PlayerConsumed.ConsumerId
+		s.watch()
+	}
+}
+
+func (s *Spectating) handlePlayerDirection(senderId uint64, _ *packets.Packet_PlayerDirection) {
+	if senderId == s.client.Id() {
+		s.rejectInput()
+	}
+}
+
+// 观战的人也可以在房间里聊天
+func (s *Spectating) handleChat(senderId uint64, message *packets.Packet_Chat) {
+	if senderId == s.client.Id() {
+		s.client.Broadcast(message)
+	} else {
+		s.client.SocketSendAs(message, senderId)
+	}
+}
+
+// 换一个房间或者跟随的玩家
+func (s *Spectating) handleSpectateRequest(senderId uint64, message *packets.Packet_SpectateRequest) {
+	if senderId != s.client.Id() {
+		return
+	}
+
+	room, ok := spectateRoom(s.client, message.SpectateRequest.RoomId, message.SpectateRequest.PlayerId)
+	if !ok {
+		return
+	}
+
+	s.roomId = room.Id
+	s.followId = message.SpectateRequest.PlayerId
+	s.client.SocketSend(packets.NewOkResponse())
+	s.watch()
+}
+
+func (s *Spectating) handleQueueRequest(senderId uint64, message *packets.Packet_QueueRequest) {
+	if senderId != s.client.Id() {
+		return
+	}
+
+	s.client.SetState(&Matchmaking{
+		user:   loadUser(s.client, s.logger, s.username),
+		party:  message.QueueRequest.PartyId,
+		region: message.QueueRequest.Region,
+	})
+}
+
+func (s *Spectating) handleJoinRoomRequest(senderId uint64, message *packets.Packet_JoinRoomRequest) {
+	if senderId != s.client.Id() {
+		return
+	}
+
+	if _, ok := joinRoom(s.client, s.logger, message.JoinRoomRequest.RoomId); ok {
+		user := loadUser(s.client, s.logger, s.username)
+		s.client.SetState(&InGame{
+			player:   newPlayer(user),
+			userId:   user.ID,
+			username: s.username,
+		})
+	}
+}
+
+func (s *Spectating) handleLeaveGameRequest(senderId uint64, _ *packets.Packet_LeaveGameRequest) {
+	if senderId == s.client.Id() {
+		s.client.SetState(&Lobby{username: s.username})
+	}
+}
+
+func (s *Spectating) handleRoomListRequest(senderId uint64, _ *packets.Packet_RoomListRequest) {
+	if senderId == s.client.Id() {
+		sendRoomList(s.client)
+	}
+}
+
+func (s *Spectating) handleLeaderboardRequest(senderId uint64, message *packets.Packet_LeaderboardRequest) {
+	if senderId == s.client.Id() {
+		sendLeaderboard(s.client, s.logger, message.LeaderboardRequest)
+	}
+}
+
+func (s *Spectating) rejectInput() {
+	s.client.SocketSend(packets.NewDenyResponse("Spectators cannot play - queue or join a room first"))
+}
+
+// 找到要观看的房间，失败时已经回复了客户端。roomId 为 0 时看 playerId 所在的房间，都为 0 时看第一个房间
+func spectateRoom(client server.ClientInterfacer, roomId uint64, playerId uint64) (*server.Room, bool) {
+	var room *server.Room
+	switch {
+	case roomId != 0:
+		found, exists := client.Rooms().Get(roomId)
+		if !exists {
+			client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Room %d does not exist", roomId)))
+			return nil, false
+		}
+		room = found
+	case playerId != 0:
+		found, exists := client.Rooms().RoomOf(playerId)
+		if !exists {
+			client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Player %d is not playing", playerId)))
+			return nil, false
+		}
+		room = found
+	default:
+		rooms := client.Rooms().Rooms()
+		if len(rooms) == 0 {
+			client.SocketSend(packets.NewDenyResponse("There is no room to spectate"))
+			return nil, false
+		}
+		room = rooms[0]
+	}
+
+	if playerId != 0 {
+		if playerRoom, exists := client.Rooms().RoomOf(playerId); !exists || playerRoom != room {
+			client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Player %d is not in room %d", playerId, room.Id)))
+			return nil, false
+		}
+	}
+
+	return room, true
+}
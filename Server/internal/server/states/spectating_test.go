@@ -0,0 +1,131 @@
+package states_test
+
+import (
+	"strings"
+	"testing"
+
+	clients "server/internal/server/Clients"
+	"server/internal/server/config"
+	"server/pkg/packets"
+)
+
+func newSpectateRequest(roomId uint64, playerId uint64) packets.Msg {
+	return &packets.Packet_SpectateRequest{
+		SpectateRequest: &packets.SpectateRequestMessage{RoomId: roomId, PlayerId: playerId},
+	}
+}
+
+// 客户端收到的第 n 个观战信息
+func spectating(t *testing.T, client *clients.LoopbackClient, n int) *packets.SpectatingMessage {
+	t.Helper()
+
+	msgs, ok := client.WaitForCount(isMsg[*packets.Packet_Spectating], n, waitTimeout)
+	if !ok {
+		t.Fatalf("client %d did not receive spectating message %d", client.Id(), n)
+	}
+	return msgs[n-1].Msg.(*packets.Packet_Spectating).Spectating
+}
+
+func TestSpectating(t *testing.T) {
+	hub := newTestHub(t)
+	alice := joinGame(t, hub, "alice")
+	bob := joinGame(t, hub, "bob")
+	carol := joinLobby(t, hub, "carol")
+	room, _ := hub.Rooms.RoomOf(alice.Id())
+
+	for _, test := range []struct {
+		name     string
+		msg      packets.Msg
+		wantDeny string
+	}{
+		{name: "unknown room", msg: newSpectateRequest(999, 0), wantDeny: "does not exist"},
+		{name: "player not playing", msg: newSpectateRequest(0, 999), wantDeny: "not playing"},
+		{name: "player in another room", msg: newSpectateRequest(room.Id, carol.Id()), wantDeny: "not in room"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			deny, isDeny := request(t, carol, test.msg).(*packets.Packet_DenyResponse)
+			if !isDeny || !strings.Contains(deny.DenyResponse.Reason, test.wantDeny) {
+				t.Fatalf("got %v, want deny %q", deny, test.wantDeny)
+			}
+		})
+	}
+
+	if _, isOk := request(t, carol, newSpectateRequest(0, alice.Id())).(*packets.Packet_OkResponse); !isOk {
+		t.Fatal("spectating alice was denied")
+	}
+	if watching := spectating(t, carol, 1); watching.RoomId != room.Id || watching.PlayerId != alice.Id() {
+		t.Errorf("got spectating %v, want room %d following %d", watching, room.Id, alice.Id())
+	}
+
+	// 观战的人收到房间的更新，但是不在世界里，也不占房间的位置
+	waitForTicks(t, carol, 2)
+	if _, inRoom := hub.Rooms.RoomOf(carol.Id()); inRoom {
+		t.Error("carol took a place in the room")
+	}
+	if _, exists := room.SharedGameObjects.Players.Get(carol.Id()); exists {
+		t.Error("carol has a player in the world")
+	}
+
+	deny, isDeny := request(t, carol, &packets.Packet_PlayerDirection{PlayerDirection: &packets.PlayerDirectionMessage{Direction: 1}}).(*packets.Packet_DenyResponse)
+	if !isDeny || !strings.Contains(deny.DenyResponse.Reason, "Spectators cannot play") {
+		t.Errorf("got %v for a direction, want deny", deny)
+	}
+
+	// 和房间里的玩家互相聊天
+	bob.Inject(packets.NewChat("hello watchers"))
+	if _, ok := carol.WaitFor(isChatFrom(bob), waitTimeout); !ok {
+		t.Error("carol did not get bob's room chat")
+	}
+	carol.Inject(packets.NewChat("nice play"))
+	if _, ok := alice.WaitFor(isChatFrom(carol), waitTimeout); !ok {
+		t.Error("alice did not get carol's chat")
+	}
+
+	if _, isOk := request(t, carol, newSpectateRequest(0, bob.Id())).(*packets.Packet_OkResponse); !isOk {
+		t.Fatal("switching to bob was denied")
+	}
+	if watching := spectating(t, carol, 2); watching.PlayerId != bob.Id() {
+		t.Errorf("got spectating %v, want following %d", watching, bob.Id())
+	}
+
+	// 跟随的玩家被吞并后跟着吞并它的玩家
+	carol.ProcessMessage(0, packets.NewPlayerConsumed(bob.Id(), alice.Id()))
+	if watching := spectating(t, carol, 3); watching.PlayerId != alice.Id() {
+		t.Errorf("got spectating %v after bob was consumed, want following %d", watching, alice.Id())
+	}
+
+	// 从观战直接开始游戏
+	carol.Inject(newQueueRequest("", ""))
+	if _, ok := carol.WaitFor(isOwnPlayer(carol), waitTimeout); !ok {
+		t.Fatal("carol never entered the game from spectating")
+	}
+	if _, watching := hub.Spectators.Get(carol.Id()); watching {
+		t.Error("carol is still spectating while playing")
+	}
+}
+
+func TestSpectateOnDeath(t *testing.T) {
+	hub := newTestHub(t, func(cfg *config.Config) {
+		cfg.World.SpectateOnDeath = true
+	})
+	alice := joinGame(t, hub, "alice")
+	bob := joinGame(t, hub, "bob")
+	room, _ := hub.Rooms.RoomOf(alice.Id())
+
+	alice.ProcessMessage(0, packets.NewPlayerConsumed(alice.Id(), bob.Id()))
+
+	if watching := spectating(t, alice, 1); watching.RoomId != room.Id || watching.PlayerId != bob.Id() {
+		t.Errorf("got spectating %v, want room %d following %d", watching, room.Id, bob.Id())
+	}
+	if inWorld(hub, alice.Id()) || count(alice.Sent(), isOwnPlayer(alice)) != 1 {
+		t.Error("alice respawned instead of spectating")
+	}
+
+	alice.Inject(&packets.Packet_LeaveGameRequest{LeaveGameRequest: &packets.LeaveGameRequestMessage{}})
+	if _, ok := alice.WaitForCount(isMsg[*packets.Packet_Profile], 2, waitTimeout); !ok {
+		t.Fatal("alice did not return to the lobby")
+	}
+	if _, watching := hub.Spectators.Get(alice.Id()); watching {
+		t.Error("alice is still spectating in the lobby")
+	}
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"log"
+	"math"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"time"
+)
+
+// 吞并其他玩家需要的质量倍数
+const ConsumeMassRatio = 1.5
+
+// 客户端交给 Hub 的输入，在下一次 tick 开始时统一处理
+type PlayerInput struct {
+	ClientId uint64
+	Msg      packets.Msg
+}
+
+// 带 ID 的玩家，按 ID 排序后保证每次 tick 的处理顺序一致
+type playerEntry struct {
+	id     uint64
+	player *objects.Player
+}
+
+// 一次吞并：房间里的 consumerId 吞并了 playerId
+type consumption struct {
+	room       *Room
+	playerId   uint64
+	consumerId uint64
+}
+
+// 执行一次世界模拟：处理输入，推进玩家，检测碰撞，最后给每个客户端发出一次合并的状态更新
+func (h *Hub) tick(delta float64) {
+	inputs := h.pendingInputs
+	h.pendingInputs = nil
+
+	for _, input := range inputs {
+		h.applyInput(input)
+	}
+
+	// 断线超过保留时间的玩家从世界里删除，也让出房间的位置
+	for _, playerId := range h.Sessions.Expire(time.Now()) {
+		log.Printf("Session for player %d expired, removing player", playerId)
+		h.Rooms.Leave(playerId)
+		h.PeakRadii.Remove(playerId)
+	}
+
+	// 每个房间是一个独立的世界
+	var consumptions []consumption
+	for _, room := range h.Rooms.Rooms() {
+		consumptions = append(consumptions, h.tickRoom(room, delta)...)
+	}
+
+	// 所有房间都结算完之后再通知被吞并的客户端，它们换状态时世界已经不会再变
+	for _, c := range consumptions {
+		h.sendPlayerConsumed(c.room, c.playerId, c.consumerId)
+	}
+
+	// 每个客户端只收到自己视野内的状态
+	h.updateInterests()
+}
+
+// 推进一个房间里的玩家并结算碰撞，返回这次的吞并
+func (h *Hub) tickRoom(room *Room, delta float64) []consumption {
+	players := sortedPlayers(room)
+
+	for _, entry := range players {
+		// 断线的玩家停在原地等待重连
+		if h.Sessions.IsDisconnected(entry.id) {
+			continue
+		}
+
+		entry.player.X += entry.player.Speed * math.Cos(entry.player.Direction) * delta
+		entry.player.Y += entry.player.Speed * math.Sin(entry.player.Direction) * delta
+		room.SharedGameObjects.Players.Update(entry.id)
+	}
+
+	return h.resolveCollisions(room, players)
+}
+
+// 处理单个客户端输入
+func (h *Hub) applyInput(input *PlayerInput) {
+	switch msg := input.Msg.(type) {
+	case *packets.Packet_PlayerDirection:
+		room, exists := h.Rooms.RoomOf(input.ClientId)
+		if !exists {
+			return
+		}
+		if player, exists := room.SharedGameObjects.Players.Get(input.ClientId); exists {
+			player.Direction = msg.PlayerDirection.Direction
+		}
+	case *packets.Packet_SnapshotAck:
+		if interest, exists := h.interests[input.ClientId]; exists {
+			interest.snapshots.ack(msg.SnapshotAck.Sequence)
+		}
+	case *packets.Packet_ReconnectRequest:
+		h.resumePlayer(input.ClientId)
+	}
+}
+
+// 重连的客户端接回了还在世界里的玩家。玩家已经在 tick 里移动，只能在这里读它的状态发给客户端
+func (h *Hub) resumePlayer(clientId uint64) {
+	client, exists := h.Clients.Get(clientId)
+	if !exists {
+		return
+	}
+	room, exists := h.Rooms.RoomOf(clientId)
+	if !exists {
+		return
+	}
+	if player, exists := room.SharedGameObjects.Players.Get(clientId); exists {
+		client.ProcessMessage(0, packets.NewPlayer(clientId, player))
+	}
+}
+
+// 房间里按 ID 排序的玩家列表
+func sortedPlayers(room *Room) []playerEntry {
+	players := make([]playerEntry, 0, room.SharedGameObjects.Players.Len())
+	room.SharedGameObjects.Players.ForEach(func(id uint64, player *objects.Player) {
+		players = append(players, playerEntry{id: id, player: player})
+	})
+
+	slices.SortFunc(players, func(a, b playerEntry) int {
+		switch {
+		case a.id < b.id:
+			return -1
+		case a.id > b.id:
+			return 1
+		}
+		return 0
+	})
+
+	return players
+}
+
+// 服务器自己检测重叠，结算吃孢子和吞并玩家，不再相信客户端的上报
+// 重叠的对象通过空间索引查找
+// 吞并只记录下来，由调用方在 tick 结束前通知
+func (h *Hub) resolveCollisions(room *Room, players []playerEntry) []consumption {
+	consumed := make(map[uint64]bool)
+	var consumptions []consumption
+
+	for _, entry := range players {
+		if consumed[entry.id] {
+			continue
+		}
+
+		// 只检查附近格子里的孢子
+		room.SharedGameObjects.Spores.ForEachInRadius(entry.player.X, entry.player.Y, entry.player.Radius, func(sporeId uint64, spore *objects.Spore) {
+			entry.player.Radius = nextRadius(entry.player.Radius, radToMass(spore.Radius))
+			room.SharedGameObjects.Spores.Remove(sporeId)
+			room.SharedGameObjects.Players.Update(entry.id)
+			h.PeakRadii.Record(entry.id, entry.player.Radius)
+
+			h.sendSporeConsumed(room, sporeId, entry.id)
+		})
+
+		room.SharedGameObjects.Players.ForEachInRadius(entry.player.X, entry.player.Y, entry.player.Radius, func(otherId uint64, other *objects.Player) {
+			if otherId == entry.id || consumed[otherId] || !canConsume(entry.player, other) {
+				return
+			}
+
+			log.Printf("Player %d consumed player %d", entry.id, otherId)
+
+			entry.player.Radius = nextRadius(entry.player.Radius, radToMass(other.Radius))
+			room.SharedGameObjects.Players.Remove(otherId)
+			room.SharedGameObjects.Players.Update(entry.id)
+			h.PeakRadii.Record(entry.id, entry.player.Radius)
+			consumed[otherId] = true
+
+			consumptions = append(consumptions, consumption{room: room, playerId: otherId, consumerId: entry.id})
+		})
+	}
+
+	return consumptions
+}
+
+// 质量超过对方的 1.5 倍才能吞并
+func canConsume(player *objects.Player, other *objects.Player) bool {
+	return radToMass(player.Radius) > radToMass(other.Radius)*ConsumeMassRatio
+}
+
+// 计算圆的面积
+func radToMass(radius float64) float64 {
+	return math.Pi * radius * radius
+}
+
+func massToRad(mass float64) float64 {
+	return math.Sqrt(mass / math.Pi)
+}
+
+// 计算吃下后的半径
+func nextRadius(radius float64, massDiff float64) float64 {
+	return massToRad(radToMass(radius) + massDiff)
+}
+
+// 半径对应的玩家质量，和实时排行榜里的一样
+func PlayerMass(radius float64) float64 {
+	return radToMass(radius)
+}
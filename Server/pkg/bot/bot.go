@@ -0,0 +1,165 @@
+// Package bot is a headless client that speaks the packets.Packet protocol. Bots log in
+// like a normal player and steer with a simple AI, for load testing and for keeping
+// quiet servers from feeling empty.
+package bot
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"time"
+
+	"server/pkg/packets"
+)
+
+// 方向变化小于这个值时不重新发送
+const directionEpsilon = 0.01
+
+type phase int
+
+const (
+	phaseHandshake phase = iota
+	phaseRegister
+	phaseLogin
+	phaseInGame
+)
+
+type Bot struct {
+	Username string
+	Password string
+
+	conn   Conn
+	world  *World
+	brain  *Brain
+	logger *log.Logger
+
+	phase     phase
+	behavior  Behavior
+	direction float64
+}
+
+func New(conn Conn, username string, password string) *Bot {
+	seed := fnv.New64a()
+	seed.Write([]byte(username))
+
+	return &Bot{
+		Username: username,
+		Password: password,
+		conn:     conn,
+		world:    NewWorld(),
+		brain:    NewBrain(seed.Sum64()),
+		logger:   log.New(log.Writer(), fmt.Sprintf("Bot %s: ", username), log.LstdFlags),
+		// 保证第一次决定之后一定会发送方向
+		direction: math.NaN(),
+	}
+}
+
+// Run registers the bot (an existing account is fine), logs in and plays until the
+// server closes the connection, sends a shutdown message or ctx is done. The
+// connection is closed when Run returns.
+func (b *Bot) Run(ctx context.Context) error {
+	defer b.conn.Close()
+
+	stop := context.AfterFunc(ctx, func() {
+		b.conn.Close()
+	})
+	defer stop()
+
+	if err := b.conn.Send(packets.NewHello(packets.ProtocolVersion, packets.SupportedFeatures)); err != nil {
+		return err
+	}
+
+	for {
+		packet, err := b.conn.Receive()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		done, err := b.handle(packet)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+func (b *Bot) handle(packet *packets.Packet) (bool, error) {
+	switch message := packet.Msg.(type) {
+	case *packets.Packet_Hello:
+		b.phase = phaseRegister
+		return false, b.conn.Send(&packets.Packet_RegisterRequest{
+			RegisterRequest: &packets.RegisterRequestMessage{Username: b.Username, Password: b.Password},
+		})
+	case *packets.Packet_OkResponse:
+		return false, b.handleResponse("")
+	case *packets.Packet_DenyResponse:
+		return false, b.handleResponse(message.DenyResponse.Reason)
+	case *packets.Packet_Shutdown:
+		b.logger.Printf("Server is shutting down: %s", message.Shutdown.Reason)
+		return true, nil
+	case *packets.Packet_Death:
+		// 不看死亡画面，马上重生
+		b.logger.Printf("Consumed by %s (player %d), respawning", message.Death.KillerName, message.Death.KillerId)
+		return false, b.conn.Send(&packets.Packet_RespawnRequest{RespawnRequest: &packets.RespawnRequestMessage{}})
+	}
+
+	if ack := b.world.Apply(packet.Msg); ack != 0 {
+		if err := b.conn.Send(packets.NewSnapshotAck(ack)); err != nil {
+			return false, err
+		}
+		return false, b.steer()
+	}
+
+	return false, nil
+}
+
+// 注册失败（一般是账号已经存在）也继续登录，登录失败就退出
+func (b *Bot) handleResponse(denyReason string) error {
+	switch b.phase {
+	case phaseHandshake:
+		if denyReason != "" {
+			return fmt.Errorf("handshake denied: %s", denyReason)
+		}
+	case phaseRegister:
+		b.phase = phaseLogin
+		return b.conn.Send(&packets.Packet_LoginRequest{
+			LoginRequest: &packets.LoginRequestMessage{Username: b.Username, Password: b.Password},
+		})
+	case phaseLogin:
+		if denyReason != "" {
+			return fmt.Errorf("login denied: %s", denyReason)
+		}
+		b.phase = phaseInGame
+		b.logger.Println("Logged in")
+
+		// 登录之后在大厅里，直接排队进入游戏
+		return b.conn.Send(&packets.Packet_QueueRequest{QueueRequest: &packets.QueueRequestMessage{}})
+	}
+	return nil
+}
+
+// 每收到一个快照决定一次方向，变化了才发送
+func (b *Bot) steer() error {
+	behavior, direction, ok := b.brain.Decide(b.world, time.Now())
+	if !ok {
+		return nil
+	}
+
+	if behavior != b.behavior {
+		b.logger.Printf("Switching from %s to %s", b.behavior, behavior)
+		b.behavior = behavior
+	}
+
+	if math.Abs(direction-b.direction) < directionEpsilon {
+		return nil
+	}
+	b.direction = direction
+
+	return b.conn.Send(&packets.Packet_PlayerDirection{
+		PlayerDirection: &packets.PlayerDirectionMessage{Direction: direction},
+	})
+}
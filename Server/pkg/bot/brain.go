@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"server/pkg/packets"
+)
+
+// 和服务器的 ConsumeMassRatio 一样：质量超过对方的 1.5 倍才能吞并
+const consumeMassRatio = 1.5
+
+type Behavior int
+
+const (
+	// 没有目标，随机换方向
+	Wander Behavior = iota
+	// 去吃最近的孢子
+	SeekSpore
+	// 躲开能吞并自己的玩家
+	Flee
+	// 追能被自己吞并的玩家
+	Chase
+)
+
+func (b Behavior) String() string {
+	switch b {
+	case Wander:
+		return "wander"
+	case SeekSpore:
+		return "seek"
+	case Flee:
+		return "flee"
+	case Chase:
+		return "chase"
+	}
+	return "unknown"
+}
+
+// A simple steering AI: flee from bigger players that are close, otherwise chase smaller
+// players, otherwise eat the nearest spore, otherwise wander.
+type Brain struct {
+	// 边缘距离小于这个值的威胁需要躲开
+	FleeDistance float64
+	// 边缘距离小于这个值的猎物才会去追
+	ChaseDistance float64
+
+	rng              *rand.Rand
+	wanderDirection  float64
+	nextWanderChange time.Time
+}
+
+func NewBrain(seed uint64) *Brain {
+	return &Brain{
+		FleeDistance:  200,
+		ChaseDistance: 400,
+		rng:           rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+	}
+}
+
+// Decide picks a behavior and the direction to move in. It returns false until the
+// bot's own player is in the world.
+func (b *Brain) Decide(world *World, now time.Time) (Behavior, float64, bool) {
+	own, exists := world.Own()
+	if !exists {
+		return Wander, 0, false
+	}
+
+	var threat, prey *packets.PlayerMessage
+	threatGap, preyGap := b.FleeDistance, b.ChaseDistance
+
+	for id, other := range world.Players {
+		if id == world.OwnId {
+			continue
+		}
+
+		gap := math.Hypot(other.X-own.X, other.Y-own.Y) - other.Radius - own.Radius
+		switch {
+		case canConsume(other.Radius, own.Radius) && gap < threatGap:
+			threat, threatGap = other, gap
+		case canConsume(own.Radius, other.Radius) && gap < preyGap:
+			prey, preyGap = other, gap
+		}
+	}
+
+	if threat != nil {
+		return Flee, math.Atan2(own.Y-threat.Y, own.X-threat.X), true
+	}
+
+	if prey != nil {
+		return Chase, math.Atan2(prey.Y-own.Y, prey.X-own.X), true
+	}
+
+	var nearest *packets.SporeMessage
+	nearestDistance := math.Inf(1)
+	for _, spore := range world.Spores {
+		if distance := math.Hypot(spore.X-own.X, spore.Y-own.Y); distance < nearestDistance {
+			nearest, nearestDistance = spore, distance
+		}
+	}
+
+	if nearest != nil {
+		return SeekSpore, math.Atan2(nearest.Y-own.Y, nearest.X-own.X), true
+	}
+
+	if now.After(b.nextWanderChange) {
+		b.wanderDirection = b.rng.Float64() * 2 * math.Pi
+		b.nextWanderChange = now.Add(time.Duration(2+b.rng.IntN(4)) * time.Second)
+	}
+	return Wander, b.wanderDirection, true
+}
+
+func canConsume(radius float64, otherRadius float64) bool {
+	return radius*radius > otherRadius*otherRadius*consumeMassRatio
+}
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+
+	"server/pkg/packets"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+// A connection from a bot to the server. Send may be called concurrently with Receive.
+type Conn interface {
+	Send(message packets.Msg) error
+	Receive() (*packets.Packet, error)
+	Close() error
+}
+
+// 进程内的连接关闭之后 Send 和 Receive 返回的错误
+var ErrClosed = errors.New("bot connection closed")
+
+// Dial connects to address, either a WebSocket URL (ws://host:8080/ws) or a raw TCP
+// address (tcp://host:port). Frames longer than maxFrameSize are rejected.
+func Dial(address string, maxFrameSize int) (Conn, error) {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("parsing server address: %w", err)
+	}
+
+	switch parsed.Scheme {
+	case "ws", "wss":
+		conn, _, err := websocket.DefaultDialer.Dial(address, nil)
+		if err != nil {
+			return nil, err
+		}
+		conn.SetReadLimit(int64(maxFrameSize))
+		return &webSocketConn{conn: conn}, nil
+	case "tcp":
+		conn, err := net.Dial("tcp", parsed.Host)
+		if err != nil {
+			return nil, err
+		}
+		return &tcpConn{conn: conn, reader: bufio.NewReader(conn), maxFrameSize: maxFrameSize}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported scheme %q (want ws, wss or tcp)", parsed.Scheme)
+}
+
+type webSocketConn struct {
+	conn     *websocket.Conn
+	writeMux sync.Mutex
+}
+
+func (c *webSocketConn) Send(message packets.Msg) error {
+	data, err := proto.Marshal(&packets.Packet{Msg: message})
+	if err != nil {
+		return err
+	}
+
+	c.writeMux.Lock()
+	defer c.writeMux.Unlock()
+
+	return c.conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+func (c *webSocketConn) Receive() (*packets.Packet, error) {
+	_, data, err := c.conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	// 服务器在每个包后面多写了一个换行
+	if len(data) > 0 && data[len(data)-1] == '\n' {
+		data = data[:len(data)-1]
+	}
+
+	packet := &packets.Packet{}
+	if err := proto.Unmarshal(data, packet); err != nil {
+		return nil, fmt.Errorf("unmarshalling packet: %w", err)
+	}
+	return packet, nil
+}
+
+func (c *webSocketConn) Close() error {
+	return c.conn.Close()
+}
+
+type tcpConn struct {
+	conn         net.Conn
+	reader       *bufio.Reader
+	maxFrameSize int
+	writeMux     sync.Mutex
+}
+
+func (c *tcpConn) Send(message packets.Msg) error {
+	c.writeMux.Lock()
+	defer c.writeMux.Unlock()
+
+	return packets.WriteFrame(c.conn, &packets.Packet{Msg: message})
+}
+
+func (c *tcpConn) Receive() (*packets.Packet, error) {
+	return packets.ReadFrame(c.reader, c.maxFrameSize)
+}
+
+func (c *tcpConn) Close() error {
+	return c.conn.Close()
+}
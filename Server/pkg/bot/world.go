@@ -0,0 +1,127 @@
+package bot
+
+import (
+	"maps"
+
+	"server/pkg/packets"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// 保留最近收到的快照数量，和服务器的 SnapshotHistorySize 一样
+const snapshotHistorySize = 32
+
+type snapshot struct {
+	sequence uint64
+	players  map[uint64]*packets.PlayerMessage
+}
+
+// The bot's view of the world, rebuilt from the snapshots, deltas and spore updates the
+// server sends. Players only come from snapshots; maps stored in the history are never
+// modified after they are built.
+type World struct {
+	OwnId   uint64
+	Players map[uint64]*packets.PlayerMessage
+	Spores  map[uint64]*packets.SporeMessage
+
+	history [snapshotHistorySize]*snapshot
+}
+
+func NewWorld() *World {
+	return &World{
+		Players: make(map[uint64]*packets.PlayerMessage),
+		Spores:  make(map[uint64]*packets.SporeMessage),
+	}
+}
+
+// 自己的玩家，还没有收到快照时返回 false
+func (w *World) Own() (*packets.PlayerMessage, bool) {
+	player, exists := w.Players[w.OwnId]
+	return player, exists
+}
+
+// Apply updates the world from a message sent by the server. It returns the sequence
+// of the snapshot to acknowledge, or 0 when there is nothing to acknowledge.
+func (w *World) Apply(message packets.Msg) uint64 {
+	switch message := message.(type) {
+	case *packets.Packet_Id:
+		w.OwnId = message.Id.Id
+	case *packets.Packet_Snapshot:
+		players := make(map[uint64]*packets.PlayerMessage, len(message.Snapshot.Players))
+		for _, player := range message.Snapshot.Players {
+			players[player.Id] = player
+		}
+		return w.store(message.Snapshot.Sequence, players)
+	case *packets.Packet_SnapshotDelta:
+		return w.applyDelta(message.SnapshotDelta)
+	case *packets.Packet_Spore:
+		w.Spores[message.Spore.Id] = message.Spore
+	case *packets.Packet_SporesBatch:
+		for _, spore := range message.SporesBatch.Spores {
+			w.Spores[spore.Id] = spore
+		}
+	case *packets.Packet_SporeConsumed:
+		delete(w.Spores, message.SporeConsumed.SporeId)
+	case *packets.Packet_RoomJoined:
+		// 新房间里的玩家和孢子和之前的没有关系
+		clear(w.Players)
+		clear(w.Spores)
+	case *packets.Packet_OutOfView:
+		// 离开视野的玩家在下一个快照里就没有了
+		for _, id := range message.OutOfView.SporeIds {
+			delete(w.Spores, id)
+		}
+	}
+	return 0
+}
+
+// 在确认过的基准上应用差量，基准已经不在记录里时丢掉这个差量，等服务器重新发送完整快照
+func (w *World) applyDelta(delta *packets.SnapshotDeltaMessage) uint64 {
+	base := w.history[delta.BaseSequence%snapshotHistorySize]
+	if base == nil || base.sequence != delta.BaseSequence {
+		return 0
+	}
+
+	players := maps.Clone(base.players)
+
+	for _, player := range delta.Added {
+		players[player.Id] = player
+	}
+
+	for _, change := range delta.Changed {
+		old, exists := players[change.Id]
+		if !exists {
+			continue
+		}
+
+		player := proto.Clone(old).(*packets.PlayerMessage)
+		if change.ChangedFields&packets.PlayerFieldX != 0 {
+			player.X = change.X
+		}
+		if change.ChangedFields&packets.PlayerFieldY != 0 {
+			player.Y = change.Y
+		}
+		if change.ChangedFields&packets.PlayerFieldRadius != 0 {
+			player.Radius = change.Radius
+		}
+		if change.ChangedFields&packets.PlayerFieldDirection != 0 {
+			player.Direction = change.Direction
+		}
+		if change.ChangedFields&packets.PlayerFieldSpeed != 0 {
+			player.Speed = change.Speed
+		}
+		players[change.Id] = player
+	}
+
+	for _, id := range delta.Removed {
+		delete(players, id)
+	}
+
+	return w.store(delta.Sequence, players)
+}
+
+func (w *World) store(sequence uint64, players map[uint64]*packets.PlayerMessage) uint64 {
+	w.history[sequence%snapshotHistorySize] = &snapshot{sequence: sequence, players: players}
+	w.Players = players
+	return sequence
+}
@@ -0,0 +1,61 @@
+package packets
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// UDP 数据报头部的标志位
+const (
+	// 需要对方确认，丢了会重发，按序号顺序交给状态机
+	DatagramReliable byte = 1 << iota
+	// 确认一个可靠的数据报，Sequence 是被确认的序号，没有内容
+	DatagramAck
+	// 保持连接，没有内容
+	DatagramPing
+	// 断开连接，没有内容
+	DatagramClose
+	// 新地址的第一步：请求 cookie，或者带着服务器给的 cookie 回来，内容是 DatagramCookieSize 字节
+	DatagramCookie
+)
+
+// 1 字节标志位 + 4 字节大端序的序号
+const DatagramHeaderSize = 5
+
+// IPv4 上一个 UDP 数据报最多的字节数，和 Packet 的最大长度
+const (
+	MaxDatagramSize        = 65507
+	MaxDatagramPayloadSize = MaxDatagramSize - DatagramHeaderSize
+)
+
+// 服务器发的 cookie 的长度。请求 cookie 的数据报也要这么长，回复不会比请求长
+const DatagramCookieSize = 16
+
+// A single UDP datagram. Reliable and unreliable datagrams are numbered separately,
+// both starting from 1; a stale unreliable datagram (e.g. an old snapshot) is dropped
+// instead of being applied after a newer one.
+type Datagram struct {
+	Flags    byte
+	Sequence uint32
+	Payload  []byte
+}
+
+func (d Datagram) Encode() []byte {
+	data := make([]byte, DatagramHeaderSize+len(d.Payload))
+	data[0] = d.Flags
+	binary.BigEndian.PutUint32(data[1:DatagramHeaderSize], d.Sequence)
+	copy(data[DatagramHeaderSize:], d.Payload)
+	return data
+}
+
+func DecodeDatagram(data []byte) (Datagram, error) {
+	if len(data) < DatagramHeaderSize {
+		return Datagram{}, fmt.Errorf("datagram of %d bytes is shorter than its header", len(data))
+	}
+
+	return Datagram{
+		Flags:    data[0],
+		Sequence: binary.BigEndian.Uint32(data[1:DatagramHeaderSize]),
+		Payload:  data[DatagramHeaderSize:],
+	}, nil
+}
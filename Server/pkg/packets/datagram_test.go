@@ -0,0 +1,39 @@
+package packets
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDatagramFraming(t *testing.T) {
+	tests := []struct {
+		name     string
+		datagram Datagram
+		want     []byte
+	}{
+		{name: "ack", datagram: Datagram{Flags: DatagramAck, Sequence: 7}, want: []byte{DatagramAck, 0, 0, 0, 7}},
+		{name: "reliable", datagram: Datagram{Flags: DatagramReliable, Sequence: 0x01020304, Payload: []byte{9, 8}}, want: []byte{DatagramReliable, 1, 2, 3, 4, 9, 8}},
+		{name: "largest sequence", datagram: Datagram{Sequence: 0xFFFFFFFF}, want: []byte{0, 0xFF, 0xFF, 0xFF, 0xFF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.datagram.Encode()
+			if !bytes.Equal(data, tt.want) {
+				t.Fatalf("Encode() = %v, want %v", data, tt.want)
+			}
+
+			decoded, err := DecodeDatagram(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Flags != tt.datagram.Flags || decoded.Sequence != tt.datagram.Sequence || !bytes.Equal(decoded.Payload, tt.datagram.Payload) {
+				t.Errorf("DecodeDatagram() = %+v, want %+v", decoded, tt.datagram)
+			}
+		})
+	}
+
+	if _, err := DecodeDatagram([]byte{DatagramAck, 0, 0, 0}); err == nil {
+		t.Error("decoded a datagram shorter than its header")
+	}
+}
@@ -0,0 +1,53 @@
+package packets
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// 流式传输（TCP）上每个包前面是 4 字节大端序的长度
+const FrameHeaderSize = 4
+
+// WriteFrame writes packet to w as a length-prefixed protobuf frame.
+func WriteFrame(w io.Writer, packet *Packet) error {
+	data, err := proto.Marshal(packet)
+	if err != nil {
+		return fmt.Errorf("marshalling %T packet: %w", packet.Msg, err)
+	}
+
+	frame := make([]byte, FrameHeaderSize+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[FrameHeaderSize:], data)
+
+	_, err = w.Write(frame)
+	return err
+}
+
+// ReadFrame reads one length-prefixed frame from r. Frames longer than maxSize are
+// rejected before their body is read, since the connection can't be trusted after that.
+func ReadFrame(r io.Reader, maxSize int) (*Packet, error) {
+	var header [FrameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if uint64(size) > uint64(maxSize) {
+		return nil, fmt.Errorf("frame of %d bytes exceeds the %d byte limit", size, maxSize)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+
+	packet := &Packet{}
+	if err := proto.Unmarshal(data, packet); err != nil {
+		return nil, fmt.Errorf("unmarshalling frame: %w", err)
+	}
+
+	return packet, nil
+}
@@ -0,0 +1,46 @@
+package packets
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFraming(t *testing.T) {
+	var stream bytes.Buffer
+	sent := []*Packet{
+		{SenderId: 1, Msg: NewChat("hello")},
+		{SenderId: 2, Msg: NewId(42)},
+	}
+	for _, packet := range sent {
+		if err := WriteFrame(&stream, packet); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, want := range sent {
+		got, err := ReadFrame(&stream, 1024)
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if got.SenderId != want.SenderId || got.String() != want.String() {
+			t.Errorf("frame %d = %v, want %v", i, got, want)
+		}
+	}
+
+	if _, err := ReadFrame(&stream, 1024); !errors.Is(err, io.EOF) {
+		t.Errorf("reading past the last frame: got %v, want EOF", err)
+	}
+}
+
+// 太长的帧在读内容之前就被拒绝
+func TestFramingTooLarge(t *testing.T) {
+	header := make([]byte, FrameHeaderSize)
+	binary.BigEndian.PutUint32(header, 2048)
+
+	if _, err := ReadFrame(bytes.NewReader(header), 1024); err == nil {
+		t.Error("read a frame longer than the limit")
+	}
+}
@@ -24,6 +24,7 @@ const (
 type ChatMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Msg           string                 `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,6 +66,13 @@ func (x *ChatMessage) GetMsg() string {
 	return ""
 }
 
+func (x *ChatMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type IdMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -302,6 +310,7 @@ type PlayerMessage struct {
 	Radius        float64                `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
 	Direction     float64                `protobuf:"fixed64,6,opt,name=direction,proto3" json:"direction,omitempty"`
 	Speed         float64                `protobuf:"fixed64,7,opt,name=speed,proto3" json:"speed,omitempty"`
+	Color         uint32                 `protobuf:"varint,8,opt,name=color,proto3" json:"color,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -385,6 +394,13 @@ func (x *PlayerMessage) GetSpeed() float64 {
 	return 0
 }
 
+func (x *PlayerMessage) GetColor() uint32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
 type PlayerDirectionMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Direction     float64                `protobuf:"fixed64,1,opt,name=direction,proto3" json:"direction,omitempty"`
@@ -500,6 +516,7 @@ func (x *SporeMessage) GetRadius() float64 {
 type SporeConsumedMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SporeId       uint64                 `protobuf:"varint,1,opt,name=spore_id,json=sporeId,proto3" json:"spore_id,omitempty"`
+	PlayerId      uint64                 `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -541,6 +558,13 @@ func (x *SporeConsumedMessage) GetSporeId() uint64 {
 	return 0
 }
 
+func (x *SporeConsumedMessage) GetPlayerId() uint64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
 type SporesBatchMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Spores        []*SporeMessage        `protobuf:"bytes,1,rep,name=spores,proto3" json:"spores,omitempty"`
@@ -588,6 +612,7 @@ func (x *SporesBatchMessage) GetSpores() []*SporeMessage {
 type PlayerConsumedMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      uint64                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	ConsumerId    uint64                 `protobuf:"varint,2,opt,name=consumer_id,json=consumerId,proto3" json:"consumer_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -629,42 +654,35 @@ func (x *PlayerConsumedMessage) GetPlayerId() uint64 {
 	return 0
 }
 
-type Packet struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	SenderId uint64                 `protobuf:"varint,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*Packet_Chat
-	//	*Packet_Id
-	//	*Packet_LoginRequest
-	//	*Packet_RegisterRequest
-	//	*Packet_OkResponse
-	//	*Packet_DenyResponse
-	//	*Packet_Player
-	//	*Packet_PlayerDirection
-	//	*Packet_Spore
-	//	*Packet_SporeConsumed
-	//	*Packet_SporesBatch
-	//	*Packet_PlayerConsumed
-	Msg           isPacket_Msg `protobuf_oneof:"msg"`
+func (x *PlayerConsumedMessage) GetConsumerId() uint64 {
+	if x != nil {
+		return x.ConsumerId
+	}
+	return 0
+}
+
+type OutOfViewMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerIds     []uint64               `protobuf:"varint,1,rep,packed,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+	SporeIds      []uint64               `protobuf:"varint,2,rep,packed,name=spore_ids,json=sporeIds,proto3" json:"spore_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) Reset() {
-	*x = Packet{}
+func (x *OutOfViewMessage) Reset() {
+	*x = OutOfViewMessage{}
 	mi := &file_packets_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) String() string {
+func (x *OutOfViewMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Packet) ProtoMessage() {}
+func (*OutOfViewMessage) ProtoMessage() {}
 
-func (x *Packet) ProtoReflect() protoreflect.Message {
+func (x *OutOfViewMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_packets_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -676,190 +694,2722 @@ func (x *Packet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
-func (*Packet) Descriptor() ([]byte, []int) {
+// Deprecated: Use OutOfViewMessage.ProtoReflect.Descriptor instead.
+func (*OutOfViewMessage) Descriptor() ([]byte, []int) {
 	return file_packets_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Packet) GetSenderId() uint64 {
+func (x *OutOfViewMessage) GetPlayerIds() []uint64 {
 	if x != nil {
-		return x.SenderId
+		return x.PlayerIds
 	}
-	return 0
+	return nil
 }
 
-func (x *Packet) GetMsg() isPacket_Msg {
+func (x *OutOfViewMessage) GetSporeIds() []uint64 {
 	if x != nil {
-		return x.Msg
+		return x.SporeIds
 	}
 	return nil
 }
 
-func (x *Packet) GetChat() *ChatMessage {
+type PlayerDeltaMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChangedFields uint32                 `protobuf:"varint,2,opt,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	X             float64                `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	Radius        float64                `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
+	Direction     float64                `protobuf:"fixed64,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	Speed         float64                `protobuf:"fixed64,7,opt,name=speed,proto3" json:"speed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerDeltaMessage) Reset() {
+	*x = PlayerDeltaMessage{}
+	mi := &file_packets_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerDeltaMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerDeltaMessage) ProtoMessage() {}
+
+func (x *PlayerDeltaMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[13]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Chat); ok {
-			return x.Chat
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetId() *IdMessage {
+// Deprecated: Use PlayerDeltaMessage.ProtoReflect.Descriptor instead.
+func (*PlayerDeltaMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PlayerDeltaMessage) GetId() uint64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Id); ok {
-			return x.Id
-		}
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetLoginRequest() *LoginRequestMessage {
+func (x *PlayerDeltaMessage) GetChangedFields() uint32 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_LoginRequest); ok {
-			return x.LoginRequest
-		}
+		return x.ChangedFields
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetRegisterRequest() *RegisterRequestMessage {
+func (x *PlayerDeltaMessage) GetX() float64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_RegisterRequest); ok {
-			return x.RegisterRequest
-		}
+		return x.X
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetOkResponse() *OkResponseMessage {
+func (x *PlayerDeltaMessage) GetY() float64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_OkResponse); ok {
-			return x.OkResponse
-		}
+		return x.Y
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetDenyResponse() *DenyResponseMessage {
+func (x *PlayerDeltaMessage) GetRadius() float64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_DenyResponse); ok {
-			return x.DenyResponse
-		}
+		return x.Radius
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetPlayer() *PlayerMessage {
+func (x *PlayerDeltaMessage) GetDirection() float64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Player); ok {
-			return x.Player
-		}
+		return x.Direction
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetPlayerDirection() *PlayerDirectionMessage {
+func (x *PlayerDeltaMessage) GetSpeed() float64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerDirection); ok {
-			return x.PlayerDirection
-		}
+		return x.Speed
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetSpore() *SporeMessage {
+type SnapshotMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Players       []*PlayerMessage       `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotMessage) Reset() {
+	*x = SnapshotMessage{}
+	mi := &file_packets_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotMessage) ProtoMessage() {}
+
+func (x *SnapshotMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[14]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Spore); ok {
-			return x.Spore
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetSporeConsumed() *SporeConsumedMessage {
+// Deprecated: Use SnapshotMessage.ProtoReflect.Descriptor instead.
+func (*SnapshotMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SnapshotMessage) GetSequence() uint64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_SporeConsumed); ok {
-			return x.SporeConsumed
-		}
+		return x.Sequence
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetSporesBatch() *SporesBatchMessage {
+func (x *SnapshotMessage) GetPlayers() []*PlayerMessage {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_SporesBatch); ok {
-			return x.SporesBatch
-		}
+		return x.Players
 	}
 	return nil
 }
 
-func (x *Packet) GetPlayerConsumed() *PlayerConsumedMessage {
+type SnapshotDeltaMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	BaseSequence  uint64                 `protobuf:"varint,2,opt,name=base_sequence,json=baseSequence,proto3" json:"base_sequence,omitempty"`
+	Added         []*PlayerMessage       `protobuf:"bytes,3,rep,name=added,proto3" json:"added,omitempty"`
+	Changed       []*PlayerDeltaMessage  `protobuf:"bytes,4,rep,name=changed,proto3" json:"changed,omitempty"`
+	Removed       []uint64               `protobuf:"varint,5,rep,packed,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotDeltaMessage) Reset() {
+	*x = SnapshotDeltaMessage{}
+	mi := &file_packets_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotDeltaMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotDeltaMessage) ProtoMessage() {}
+
+func (x *SnapshotDeltaMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[15]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerConsumed); ok {
-			return x.PlayerConsumed
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type isPacket_Msg interface {
-	isPacket_Msg()
+// Deprecated: Use SnapshotDeltaMessage.ProtoReflect.Descriptor instead.
+func (*SnapshotDeltaMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{15}
 }
 
-type Packet_Chat struct {
-	Chat *ChatMessage `protobuf:"bytes,2,opt,name=chat,proto3,oneof"`
+func (x *SnapshotDeltaMessage) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
 }
 
-type Packet_Id struct {
-	Id *IdMessage `protobuf:"bytes,3,opt,name=id,proto3,oneof"`
+func (x *SnapshotDeltaMessage) GetBaseSequence() uint64 {
+	if x != nil {
+		return x.BaseSequence
+	}
+	return 0
 }
 
-type Packet_LoginRequest struct {
-	LoginRequest *LoginRequestMessage `protobuf:"bytes,4,opt,name=login_request,json=loginRequest,proto3,oneof"`
+func (x *SnapshotDeltaMessage) GetAdded() []*PlayerMessage {
+	if x != nil {
+		return x.Added
+	}
+	return nil
 }
 
-type Packet_RegisterRequest struct {
-	RegisterRequest *RegisterRequestMessage `protobuf:"bytes,5,opt,name=register_request,json=registerRequest,proto3,oneof"`
+func (x *SnapshotDeltaMessage) GetChanged() []*PlayerDeltaMessage {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
 }
 
-type Packet_OkResponse struct {
-	OkResponse *OkResponseMessage `protobuf:"bytes,6,opt,name=ok_response,json=okResponse,proto3,oneof"`
+func (x *SnapshotDeltaMessage) GetRemoved() []uint64 {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
 }
 
-type Packet_DenyResponse struct {
-	DenyResponse *DenyResponseMessage `protobuf:"bytes,7,opt,name=deny_response,json=denyResponse,proto3,oneof"`
+type SnapshotAckMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Packet_Player struct {
-	Player *PlayerMessage `protobuf:"bytes,8,opt,name=player,proto3,oneof"`
+func (x *SnapshotAckMessage) Reset() {
+	*x = SnapshotAckMessage{}
+	mi := &file_packets_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Packet_PlayerDirection struct {
-	PlayerDirection *PlayerDirectionMessage `protobuf:"bytes,9,opt,name=player_direction,json=playerDirection,proto3,oneof"`
+func (x *SnapshotAckMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Packet_Spore struct {
-	Spore *SporeMessage `protobuf:"bytes,10,opt,name=spore,proto3,oneof"`
+func (*SnapshotAckMessage) ProtoMessage() {}
+
+func (x *SnapshotAckMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Packet_SporeConsumed struct {
-	SporeConsumed *SporeConsumedMessage `protobuf:"bytes,11,opt,name=spore_consumed,json=sporeConsumed,proto3,oneof"`
+// Deprecated: Use SnapshotAckMessage.ProtoReflect.Descriptor instead.
+func (*SnapshotAckMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{16}
 }
 
-type Packet_SporesBatch struct {
-	SporesBatch *SporesBatchMessage `protobuf:"bytes,12,opt,name=spores_batch,json=sporesBatch,proto3,oneof"`
+func (x *SnapshotAckMessage) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
 }
 
-type Packet_PlayerConsumed struct {
-	PlayerConsumed *PlayerConsumedMessage `protobuf:"bytes,13,opt,name=player_consumed,json=playerConsumed,proto3,oneof"`
+type HelloMessage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProtocolVersion uint32                 `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Features        []string               `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (*Packet_Chat) isPacket_Msg() {}
+func (x *HelloMessage) Reset() {
+	*x = HelloMessage{}
+	mi := &file_packets_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (*Packet_Id) isPacket_Msg() {}
+func (x *HelloMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*Packet_LoginRequest) isPacket_Msg() {}
+func (*HelloMessage) ProtoMessage() {}
+
+func (x *HelloMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelloMessage.ProtoReflect.Descriptor instead.
+func (*HelloMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HelloMessage) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *HelloMessage) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type SessionMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionMessage) Reset() {
+	*x = SessionMessage{}
+	mi := &file_packets_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionMessage) ProtoMessage() {}
+
+func (x *SessionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionMessage.ProtoReflect.Descriptor instead.
+func (*SessionMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SessionMessage) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ReconnectRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconnectRequestMessage) Reset() {
+	*x = ReconnectRequestMessage{}
+	mi := &file_packets_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconnectRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconnectRequestMessage) ProtoMessage() {}
+
+func (x *ReconnectRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconnectRequestMessage.ProtoReflect.Descriptor instead.
+func (*ReconnectRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReconnectRequestMessage) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ShutdownMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShutdownMessage) Reset() {
+	*x = ShutdownMessage{}
+	mi := &file_packets_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShutdownMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownMessage) ProtoMessage() {}
+
+func (x *ShutdownMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShutdownMessage.ProtoReflect.Descriptor instead.
+func (*ShutdownMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ShutdownMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type LeaderboardRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Daily         bool                   `protobuf:"varint,1,opt,name=daily,proto3" json:"daily,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardRequestMessage) Reset() {
+	*x = LeaderboardRequestMessage{}
+	mi := &file_packets_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardRequestMessage) ProtoMessage() {}
+
+func (x *LeaderboardRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardRequestMessage.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LeaderboardRequestMessage) GetDaily() bool {
+	if x != nil {
+		return x.Daily
+	}
+	return false
+}
+
+func (x *LeaderboardRequestMessage) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LeaderboardEntryMessage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Rank            uint32                 `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Username        string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	PeakRadius      float64                `protobuf:"fixed64,3,opt,name=peak_radius,json=peakRadius,proto3" json:"peak_radius,omitempty"`
+	SporesEaten     uint64                 `protobuf:"varint,4,opt,name=spores_eaten,json=sporesEaten,proto3" json:"spores_eaten,omitempty"`
+	PlayersConsumed uint64                 `protobuf:"varint,5,opt,name=players_consumed,json=playersConsumed,proto3" json:"players_consumed,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntryMessage) Reset() {
+	*x = LeaderboardEntryMessage{}
+	mi := &file_packets_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntryMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntryMessage) ProtoMessage() {}
+
+func (x *LeaderboardEntryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntryMessage.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntryMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LeaderboardEntryMessage) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntryMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardEntryMessage) GetPeakRadius() float64 {
+	if x != nil {
+		return x.PeakRadius
+	}
+	return 0
+}
+
+func (x *LeaderboardEntryMessage) GetSporesEaten() uint64 {
+	if x != nil {
+		return x.SporesEaten
+	}
+	return 0
+}
+
+func (x *LeaderboardEntryMessage) GetPlayersConsumed() uint64 {
+	if x != nil {
+		return x.PlayersConsumed
+	}
+	return 0
+}
+
+type LeaderboardMessage struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Daily         bool                       `protobuf:"varint,1,opt,name=daily,proto3" json:"daily,omitempty"`
+	Entries       []*LeaderboardEntryMessage `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardMessage) Reset() {
+	*x = LeaderboardMessage{}
+	mi := &file_packets_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardMessage) ProtoMessage() {}
+
+func (x *LeaderboardMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardMessage.ProtoReflect.Descriptor instead.
+func (*LeaderboardMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LeaderboardMessage) GetDaily() bool {
+	if x != nil {
+		return x.Daily
+	}
+	return false
+}
+
+func (x *LeaderboardMessage) GetEntries() []*LeaderboardEntryMessage {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type LiveLeaderboardEntryMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint64                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Mass          float64                `protobuf:"fixed64,3,opt,name=mass,proto3" json:"mass,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiveLeaderboardEntryMessage) Reset() {
+	*x = LiveLeaderboardEntryMessage{}
+	mi := &file_packets_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiveLeaderboardEntryMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveLeaderboardEntryMessage) ProtoMessage() {}
+
+func (x *LiveLeaderboardEntryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveLeaderboardEntryMessage.ProtoReflect.Descriptor instead.
+func (*LiveLeaderboardEntryMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LiveLeaderboardEntryMessage) GetPlayerId() uint64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *LiveLeaderboardEntryMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LiveLeaderboardEntryMessage) GetMass() float64 {
+	if x != nil {
+		return x.Mass
+	}
+	return 0
+}
+
+type LiveLeaderboardMessage struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Entries       []*LiveLeaderboardEntryMessage `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	OwnRank       uint32                         `protobuf:"varint,2,opt,name=own_rank,json=ownRank,proto3" json:"own_rank,omitempty"`
+	PlayerCount   uint32                         `protobuf:"varint,3,opt,name=player_count,json=playerCount,proto3" json:"player_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiveLeaderboardMessage) Reset() {
+	*x = LiveLeaderboardMessage{}
+	mi := &file_packets_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiveLeaderboardMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveLeaderboardMessage) ProtoMessage() {}
+
+func (x *LiveLeaderboardMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveLeaderboardMessage.ProtoReflect.Descriptor instead.
+func (*LiveLeaderboardMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LiveLeaderboardMessage) GetEntries() []*LiveLeaderboardEntryMessage {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *LiveLeaderboardMessage) GetOwnRank() uint32 {
+	if x != nil {
+		return x.OwnRank
+	}
+	return 0
+}
+
+func (x *LiveLeaderboardMessage) GetPlayerCount() uint32 {
+	if x != nil {
+		return x.PlayerCount
+	}
+	return 0
+}
+
+type ChangePasswordRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequestMessage) Reset() {
+	*x = ChangePasswordRequestMessage{}
+	mi := &file_packets_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequestMessage) ProtoMessage() {}
+
+func (x *ChangePasswordRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequestMessage.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ChangePasswordRequestMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequestMessage) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequestMessage) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type DeleteAccountRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequestMessage) Reset() {
+	*x = DeleteAccountRequestMessage{}
+	mi := &file_packets_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequestMessage) ProtoMessage() {}
+
+func (x *DeleteAccountRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequestMessage.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteAccountRequestMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *DeleteAccountRequestMessage) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type KickMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KickMessage) Reset() {
+	*x = KickMessage{}
+	mi := &file_packets_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KickMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickMessage) ProtoMessage() {}
+
+func (x *KickMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickMessage.ProtoReflect.Descriptor instead.
+func (*KickMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *KickMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RoomListRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomListRequestMessage) Reset() {
+	*x = RoomListRequestMessage{}
+	mi := &file_packets_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomListRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomListRequestMessage) ProtoMessage() {}
+
+func (x *RoomListRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomListRequestMessage.ProtoReflect.Descriptor instead.
+func (*RoomListRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{29}
+}
+
+type RoomInfoMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Players       uint32                 `protobuf:"varint,2,opt,name=players,proto3" json:"players,omitempty"`
+	Capacity      uint32                 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomInfoMessage) Reset() {
+	*x = RoomInfoMessage{}
+	mi := &file_packets_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomInfoMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomInfoMessage) ProtoMessage() {}
+
+func (x *RoomInfoMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomInfoMessage.ProtoReflect.Descriptor instead.
+func (*RoomInfoMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RoomInfoMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RoomInfoMessage) GetPlayers() uint32 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+func (x *RoomInfoMessage) GetCapacity() uint32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type RoomListMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rooms         []*RoomInfoMessage     `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	CurrentRoomId uint64                 `protobuf:"varint,2,opt,name=current_room_id,json=currentRoomId,proto3" json:"current_room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomListMessage) Reset() {
+	*x = RoomListMessage{}
+	mi := &file_packets_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomListMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomListMessage) ProtoMessage() {}
+
+func (x *RoomListMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomListMessage.ProtoReflect.Descriptor instead.
+func (*RoomListMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RoomListMessage) GetRooms() []*RoomInfoMessage {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+func (x *RoomListMessage) GetCurrentRoomId() uint64 {
+	if x != nil {
+		return x.CurrentRoomId
+	}
+	return 0
+}
+
+type JoinRoomRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        uint64                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomRequestMessage) Reset() {
+	*x = JoinRoomRequestMessage{}
+	mi := &file_packets_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomRequestMessage) ProtoMessage() {}
+
+func (x *JoinRoomRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomRequestMessage.ProtoReflect.Descriptor instead.
+func (*JoinRoomRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *JoinRoomRequestMessage) GetRoomId() uint64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+type RoomJoinedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Room          *RoomInfoMessage       `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomJoinedMessage) Reset() {
+	*x = RoomJoinedMessage{}
+	mi := &file_packets_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomJoinedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomJoinedMessage) ProtoMessage() {}
+
+func (x *RoomJoinedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomJoinedMessage.ProtoReflect.Descriptor instead.
+func (*RoomJoinedMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RoomJoinedMessage) GetRoom() *RoomInfoMessage {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+type QueueRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartyId       string                 `protobuf:"bytes,1,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueRequestMessage) Reset() {
+	*x = QueueRequestMessage{}
+	mi := &file_packets_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueRequestMessage) ProtoMessage() {}
+
+func (x *QueueRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueRequestMessage.ProtoReflect.Descriptor instead.
+func (*QueueRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *QueueRequestMessage) GetPartyId() string {
+	if x != nil {
+		return x.PartyId
+	}
+	return ""
+}
+
+func (x *QueueRequestMessage) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type QueueStatusMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      uint32                 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	QueueSize     uint32                 `protobuf:"varint,2,opt,name=queue_size,json=queueSize,proto3" json:"queue_size,omitempty"`
+	WaitedMs      uint64                 `protobuf:"varint,3,opt,name=waited_ms,json=waitedMs,proto3" json:"waited_ms,omitempty"`
+	Rating        int64                  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	RatingSpread  uint32                 `protobuf:"varint,5,opt,name=rating_spread,json=ratingSpread,proto3" json:"rating_spread,omitempty"`
+	TargetSize    uint32                 `protobuf:"varint,6,opt,name=target_size,json=targetSize,proto3" json:"target_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueStatusMessage) Reset() {
+	*x = QueueStatusMessage{}
+	mi := &file_packets_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueStatusMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueStatusMessage) ProtoMessage() {}
+
+func (x *QueueStatusMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueStatusMessage.ProtoReflect.Descriptor instead.
+func (*QueueStatusMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *QueueStatusMessage) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *QueueStatusMessage) GetQueueSize() uint32 {
+	if x != nil {
+		return x.QueueSize
+	}
+	return 0
+}
+
+func (x *QueueStatusMessage) GetWaitedMs() uint64 {
+	if x != nil {
+		return x.WaitedMs
+	}
+	return 0
+}
+
+func (x *QueueStatusMessage) GetRating() int64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *QueueStatusMessage) GetRatingSpread() uint32 {
+	if x != nil {
+		return x.RatingSpread
+	}
+	return 0
+}
+
+func (x *QueueStatusMessage) GetTargetSize() uint32 {
+	if x != nil {
+		return x.TargetSize
+	}
+	return 0
+}
+
+type ProfileMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Color         uint32                 `protobuf:"varint,3,opt,name=color,proto3" json:"color,omitempty"`
+	Rating        int64                  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileMessage) Reset() {
+	*x = ProfileMessage{}
+	mi := &file_packets_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileMessage) ProtoMessage() {}
+
+func (x *ProfileMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileMessage.ProtoReflect.Descriptor instead.
+func (*ProfileMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ProfileMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ProfileMessage) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *ProfileMessage) GetColor() uint32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *ProfileMessage) GetRating() int64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+type SetProfileRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nickname      string                 `protobuf:"bytes,1,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Color         uint32                 `protobuf:"varint,2,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProfileRequestMessage) Reset() {
+	*x = SetProfileRequestMessage{}
+	mi := &file_packets_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProfileRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProfileRequestMessage) ProtoMessage() {}
+
+func (x *SetProfileRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProfileRequestMessage.ProtoReflect.Descriptor instead.
+func (*SetProfileRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SetProfileRequestMessage) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *SetProfileRequestMessage) GetColor() uint32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+type FriendListRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendListRequestMessage) Reset() {
+	*x = FriendListRequestMessage{}
+	mi := &file_packets_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendListRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendListRequestMessage) ProtoMessage() {}
+
+func (x *FriendListRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendListRequestMessage.ProtoReflect.Descriptor instead.
+func (*FriendListRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{38}
+}
+
+type FriendMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Online        bool                   `protobuf:"varint,3,opt,name=online,proto3" json:"online,omitempty"`
+	RoomId        uint64                 `protobuf:"varint,4,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendMessage) Reset() {
+	*x = FriendMessage{}
+	mi := &file_packets_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendMessage) ProtoMessage() {}
+
+func (x *FriendMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendMessage.ProtoReflect.Descriptor instead.
+func (*FriendMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *FriendMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *FriendMessage) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *FriendMessage) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *FriendMessage) GetRoomId() uint64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+type FriendListMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Friends       []*FriendMessage       `protobuf:"bytes,1,rep,name=friends,proto3" json:"friends,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendListMessage) Reset() {
+	*x = FriendListMessage{}
+	mi := &file_packets_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendListMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendListMessage) ProtoMessage() {}
+
+func (x *FriendListMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendListMessage.ProtoReflect.Descriptor instead.
+func (*FriendListMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *FriendListMessage) GetFriends() []*FriendMessage {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+type AddFriendRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddFriendRequestMessage) Reset() {
+	*x = AddFriendRequestMessage{}
+	mi := &file_packets_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddFriendRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddFriendRequestMessage) ProtoMessage() {}
+
+func (x *AddFriendRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddFriendRequestMessage.ProtoReflect.Descriptor instead.
+func (*AddFriendRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddFriendRequestMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type RemoveFriendRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFriendRequestMessage) Reset() {
+	*x = RemoveFriendRequestMessage{}
+	mi := &file_packets_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFriendRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFriendRequestMessage) ProtoMessage() {}
+
+func (x *RemoveFriendRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFriendRequestMessage.ProtoReflect.Descriptor instead.
+func (*RemoveFriendRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RemoveFriendRequestMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type StatsRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequestMessage) Reset() {
+	*x = StatsRequestMessage{}
+	mi := &file_packets_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequestMessage) ProtoMessage() {}
+
+func (x *StatsRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequestMessage.ProtoReflect.Descriptor instead.
+func (*StatsRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{43}
+}
+
+type StatsMessage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	GamesPlayed     uint64                 `protobuf:"varint,1,opt,name=games_played,json=gamesPlayed,proto3" json:"games_played,omitempty"`
+	PeakRadius      float64                `protobuf:"fixed64,2,opt,name=peak_radius,json=peakRadius,proto3" json:"peak_radius,omitempty"`
+	SporesEaten     uint64                 `protobuf:"varint,3,opt,name=spores_eaten,json=sporesEaten,proto3" json:"spores_eaten,omitempty"`
+	PlayersConsumed uint64                 `protobuf:"varint,4,opt,name=players_consumed,json=playersConsumed,proto3" json:"players_consumed,omitempty"`
+	TimeAliveMs     uint64                 `protobuf:"varint,5,opt,name=time_alive_ms,json=timeAliveMs,proto3" json:"time_alive_ms,omitempty"`
+	Deaths          uint64                 `protobuf:"varint,6,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StatsMessage) Reset() {
+	*x = StatsMessage{}
+	mi := &file_packets_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsMessage) ProtoMessage() {}
+
+func (x *StatsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsMessage.ProtoReflect.Descriptor instead.
+func (*StatsMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *StatsMessage) GetGamesPlayed() uint64 {
+	if x != nil {
+		return x.GamesPlayed
+	}
+	return 0
+}
+
+func (x *StatsMessage) GetPeakRadius() float64 {
+	if x != nil {
+		return x.PeakRadius
+	}
+	return 0
+}
+
+func (x *StatsMessage) GetSporesEaten() uint64 {
+	if x != nil {
+		return x.SporesEaten
+	}
+	return 0
+}
+
+func (x *StatsMessage) GetPlayersConsumed() uint64 {
+	if x != nil {
+		return x.PlayersConsumed
+	}
+	return 0
+}
+
+func (x *StatsMessage) GetTimeAliveMs() uint64 {
+	if x != nil {
+		return x.TimeAliveMs
+	}
+	return 0
+}
+
+func (x *StatsMessage) GetDeaths() uint64 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+type LeaveGameRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveGameRequestMessage) Reset() {
+	*x = LeaveGameRequestMessage{}
+	mi := &file_packets_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveGameRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveGameRequestMessage) ProtoMessage() {}
+
+func (x *LeaveGameRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveGameRequestMessage.ProtoReflect.Descriptor instead.
+func (*LeaveGameRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{45}
+}
+
+type SpectateRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        uint64                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	PlayerId      uint64                 `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateRequestMessage) Reset() {
+	*x = SpectateRequestMessage{}
+	mi := &file_packets_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateRequestMessage) ProtoMessage() {}
+
+func (x *SpectateRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateRequestMessage.ProtoReflect.Descriptor instead.
+func (*SpectateRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SpectateRequestMessage) GetRoomId() uint64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SpectateRequestMessage) GetPlayerId() uint64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+type SpectatingMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        uint64                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	PlayerId      uint64                 `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectatingMessage) Reset() {
+	*x = SpectatingMessage{}
+	mi := &file_packets_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectatingMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectatingMessage) ProtoMessage() {}
+
+func (x *SpectatingMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectatingMessage.ProtoReflect.Descriptor instead.
+func (*SpectatingMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SpectatingMessage) GetRoomId() uint64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SpectatingMessage) GetPlayerId() uint64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+type DeathMessage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	KillerId       uint64                 `protobuf:"varint,1,opt,name=killer_id,json=killerId,proto3" json:"killer_id,omitempty"`
+	KillerName     string                 `protobuf:"bytes,2,opt,name=killer_name,json=killerName,proto3" json:"killer_name,omitempty"`
+	FinalMass      float64                `protobuf:"fixed64,3,opt,name=final_mass,json=finalMass,proto3" json:"final_mass,omitempty"`
+	TimeAliveMs    uint64                 `protobuf:"varint,4,opt,name=time_alive_ms,json=timeAliveMs,proto3" json:"time_alive_ms,omitempty"`
+	RespawnDelayMs uint64                 `protobuf:"varint,5,opt,name=respawn_delay_ms,json=respawnDelayMs,proto3" json:"respawn_delay_ms,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeathMessage) Reset() {
+	*x = DeathMessage{}
+	mi := &file_packets_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeathMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeathMessage) ProtoMessage() {}
+
+func (x *DeathMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeathMessage.ProtoReflect.Descriptor instead.
+func (*DeathMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DeathMessage) GetKillerId() uint64 {
+	if x != nil {
+		return x.KillerId
+	}
+	return 0
+}
+
+func (x *DeathMessage) GetKillerName() string {
+	if x != nil {
+		return x.KillerName
+	}
+	return ""
+}
+
+func (x *DeathMessage) GetFinalMass() float64 {
+	if x != nil {
+		return x.FinalMass
+	}
+	return 0
+}
+
+func (x *DeathMessage) GetTimeAliveMs() uint64 {
+	if x != nil {
+		return x.TimeAliveMs
+	}
+	return 0
+}
+
+func (x *DeathMessage) GetRespawnDelayMs() uint64 {
+	if x != nil {
+		return x.RespawnDelayMs
+	}
+	return 0
+}
+
+type RespawnRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespawnRequestMessage) Reset() {
+	*x = RespawnRequestMessage{}
+	mi := &file_packets_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespawnRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespawnRequestMessage) ProtoMessage() {}
+
+func (x *RespawnRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespawnRequestMessage.ProtoReflect.Descriptor instead.
+func (*RespawnRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{49}
+}
+
+type Packet struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SenderId uint64                 `protobuf:"varint,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*Packet_Chat
+	//	*Packet_Id
+	//	*Packet_LoginRequest
+	//	*Packet_RegisterRequest
+	//	*Packet_OkResponse
+	//	*Packet_DenyResponse
+	//	*Packet_Player
+	//	*Packet_PlayerDirection
+	//	*Packet_Spore
+	//	*Packet_SporeConsumed
+	//	*Packet_SporesBatch
+	//	*Packet_PlayerConsumed
+	//	*Packet_OutOfView
+	//	*Packet_Snapshot
+	//	*Packet_SnapshotDelta
+	//	*Packet_SnapshotAck
+	//	*Packet_Hello
+	//	*Packet_Session
+	//	*Packet_ReconnectRequest
+	//	*Packet_Shutdown
+	//	*Packet_LeaderboardRequest
+	//	*Packet_Leaderboard
+	//	*Packet_LiveLeaderboard
+	//	*Packet_ChangePasswordRequest
+	//	*Packet_DeleteAccountRequest
+	//	*Packet_Kick
+	//	*Packet_RoomListRequest
+	//	*Packet_RoomList
+	//	*Packet_JoinRoomRequest
+	//	*Packet_RoomJoined
+	//	*Packet_QueueRequest
+	//	*Packet_QueueStatus
+	//	*Packet_Profile
+	//	*Packet_SetProfileRequest
+	//	*Packet_FriendListRequest
+	//	*Packet_FriendList
+	//	*Packet_AddFriendRequest
+	//	*Packet_RemoveFriendRequest
+	//	*Packet_StatsRequest
+	//	*Packet_Stats
+	//	*Packet_LeaveGameRequest
+	//	*Packet_SpectateRequest
+	//	*Packet_Spectating
+	//	*Packet_Death
+	//	*Packet_RespawnRequest
+	Msg           isPacket_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Packet) Reset() {
+	*x = Packet{}
+	mi := &file_packets_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Packet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Packet) ProtoMessage() {}
+
+func (x *Packet) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
+func (*Packet) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Packet) GetSenderId() uint64 {
+	if x != nil {
+		return x.SenderId
+	}
+	return 0
+}
+
+func (x *Packet) GetMsg() isPacket_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *Packet) GetChat() *ChatMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Chat); ok {
+			return x.Chat
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetId() *IdMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Id); ok {
+			return x.Id
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLoginRequest() *LoginRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LoginRequest); ok {
+			return x.LoginRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRegisterRequest() *RegisterRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RegisterRequest); ok {
+			return x.RegisterRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetOkResponse() *OkResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_OkResponse); ok {
+			return x.OkResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDenyResponse() *DenyResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_DenyResponse); ok {
+			return x.DenyResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayer() *PlayerMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Player); ok {
+			return x.Player
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerDirection() *PlayerDirectionMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerDirection); ok {
+			return x.PlayerDirection
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSpore() *SporeMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Spore); ok {
+			return x.Spore
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSporeConsumed() *SporeConsumedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SporeConsumed); ok {
+			return x.SporeConsumed
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSporesBatch() *SporesBatchMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SporesBatch); ok {
+			return x.SporesBatch
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerConsumed() *PlayerConsumedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerConsumed); ok {
+			return x.PlayerConsumed
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetOutOfView() *OutOfViewMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_OutOfView); ok {
+			return x.OutOfView
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSnapshot() *SnapshotMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSnapshotDelta() *SnapshotDeltaMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SnapshotDelta); ok {
+			return x.SnapshotDelta
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSnapshotAck() *SnapshotAckMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SnapshotAck); ok {
+			return x.SnapshotAck
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetHello() *HelloMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Hello); ok {
+			return x.Hello
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSession() *SessionMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Session); ok {
+			return x.Session
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetReconnectRequest() *ReconnectRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ReconnectRequest); ok {
+			return x.ReconnectRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetShutdown() *ShutdownMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Shutdown); ok {
+			return x.Shutdown
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLeaderboardRequest() *LeaderboardRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LeaderboardRequest); ok {
+			return x.LeaderboardRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLeaderboard() *LeaderboardMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Leaderboard); ok {
+			return x.Leaderboard
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLiveLeaderboard() *LiveLeaderboardMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LiveLeaderboard); ok {
+			return x.LiveLeaderboard
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetChangePasswordRequest() *ChangePasswordRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ChangePasswordRequest); ok {
+			return x.ChangePasswordRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDeleteAccountRequest() *DeleteAccountRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_DeleteAccountRequest); ok {
+			return x.DeleteAccountRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetKick() *KickMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Kick); ok {
+			return x.Kick
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRoomListRequest() *RoomListRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RoomListRequest); ok {
+			return x.RoomListRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRoomList() *RoomListMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RoomList); ok {
+			return x.RoomList
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetJoinRoomRequest() *JoinRoomRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_JoinRoomRequest); ok {
+			return x.JoinRoomRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRoomJoined() *RoomJoinedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RoomJoined); ok {
+			return x.RoomJoined
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetQueueRequest() *QueueRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_QueueRequest); ok {
+			return x.QueueRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetQueueStatus() *QueueStatusMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_QueueStatus); ok {
+			return x.QueueStatus
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetProfile() *ProfileMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Profile); ok {
+			return x.Profile
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSetProfileRequest() *SetProfileRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SetProfileRequest); ok {
+			return x.SetProfileRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetFriendListRequest() *FriendListRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_FriendListRequest); ok {
+			return x.FriendListRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetFriendList() *FriendListMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_FriendList); ok {
+			return x.FriendList
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetAddFriendRequest() *AddFriendRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_AddFriendRequest); ok {
+			return x.AddFriendRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRemoveFriendRequest() *RemoveFriendRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RemoveFriendRequest); ok {
+			return x.RemoveFriendRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetStatsRequest() *StatsRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_StatsRequest); ok {
+			return x.StatsRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetStats() *StatsMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Stats); ok {
+			return x.Stats
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLeaveGameRequest() *LeaveGameRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LeaveGameRequest); ok {
+			return x.LeaveGameRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSpectateRequest() *SpectateRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SpectateRequest); ok {
+			return x.SpectateRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSpectating() *SpectatingMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Spectating); ok {
+			return x.Spectating
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDeath() *DeathMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Death); ok {
+			return x.Death
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRespawnRequest() *RespawnRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RespawnRequest); ok {
+			return x.RespawnRequest
+		}
+	}
+	return nil
+}
+
+type isPacket_Msg interface {
+	isPacket_Msg()
+}
+
+type Packet_Chat struct {
+	Chat *ChatMessage `protobuf:"bytes,2,opt,name=chat,proto3,oneof"`
+}
+
+type Packet_Id struct {
+	Id *IdMessage `protobuf:"bytes,3,opt,name=id,proto3,oneof"`
+}
+
+type Packet_LoginRequest struct {
+	LoginRequest *LoginRequestMessage `protobuf:"bytes,4,opt,name=login_request,json=loginRequest,proto3,oneof"`
+}
+
+type Packet_RegisterRequest struct {
+	RegisterRequest *RegisterRequestMessage `protobuf:"bytes,5,opt,name=register_request,json=registerRequest,proto3,oneof"`
+}
+
+type Packet_OkResponse struct {
+	OkResponse *OkResponseMessage `protobuf:"bytes,6,opt,name=ok_response,json=okResponse,proto3,oneof"`
+}
+
+type Packet_DenyResponse struct {
+	DenyResponse *DenyResponseMessage `protobuf:"bytes,7,opt,name=deny_response,json=denyResponse,proto3,oneof"`
+}
+
+type Packet_Player struct {
+	Player *PlayerMessage `protobuf:"bytes,8,opt,name=player,proto3,oneof"`
+}
+
+type Packet_PlayerDirection struct {
+	PlayerDirection *PlayerDirectionMessage `protobuf:"bytes,9,opt,name=player_direction,json=playerDirection,proto3,oneof"`
+}
+
+type Packet_Spore struct {
+	Spore *SporeMessage `protobuf:"bytes,10,opt,name=spore,proto3,oneof"`
+}
+
+type Packet_SporeConsumed struct {
+	SporeConsumed *SporeConsumedMessage `protobuf:"bytes,11,opt,name=spore_consumed,json=sporeConsumed,proto3,oneof"`
+}
+
+type Packet_SporesBatch struct {
+	SporesBatch *SporesBatchMessage `protobuf:"bytes,12,opt,name=spores_batch,json=sporesBatch,proto3,oneof"`
+}
+
+type Packet_PlayerConsumed struct {
+	PlayerConsumed *PlayerConsumedMessage `protobuf:"bytes,13,opt,name=player_consumed,json=playerConsumed,proto3,oneof"`
+}
+
+type Packet_OutOfView struct {
+	OutOfView *OutOfViewMessage `protobuf:"bytes,15,opt,name=out_of_view,json=outOfView,proto3,oneof"`
+}
+
+type Packet_Snapshot struct {
+	Snapshot *SnapshotMessage `protobuf:"bytes,16,opt,name=snapshot,proto3,oneof"`
+}
+
+type Packet_SnapshotDelta struct {
+	SnapshotDelta *SnapshotDeltaMessage `protobuf:"bytes,17,opt,name=snapshot_delta,json=snapshotDelta,proto3,oneof"`
+}
+
+type Packet_SnapshotAck struct {
+	SnapshotAck *SnapshotAckMessage `protobuf:"bytes,18,opt,name=snapshot_ack,json=snapshotAck,proto3,oneof"`
+}
+
+type Packet_Hello struct {
+	Hello *HelloMessage `protobuf:"bytes,19,opt,name=hello,proto3,oneof"`
+}
+
+type Packet_Session struct {
+	Session *SessionMessage `protobuf:"bytes,20,opt,name=session,proto3,oneof"`
+}
+
+type Packet_ReconnectRequest struct {
+	ReconnectRequest *ReconnectRequestMessage `protobuf:"bytes,21,opt,name=reconnect_request,json=reconnectRequest,proto3,oneof"`
+}
+
+type Packet_Shutdown struct {
+	Shutdown *ShutdownMessage `protobuf:"bytes,22,opt,name=shutdown,proto3,oneof"`
+}
+
+type Packet_LeaderboardRequest struct {
+	LeaderboardRequest *LeaderboardRequestMessage `protobuf:"bytes,23,opt,name=leaderboard_request,json=leaderboardRequest,proto3,oneof"`
+}
+
+type Packet_Leaderboard struct {
+	Leaderboard *LeaderboardMessage `protobuf:"bytes,24,opt,name=leaderboard,proto3,oneof"`
+}
+
+type Packet_LiveLeaderboard struct {
+	LiveLeaderboard *LiveLeaderboardMessage `protobuf:"bytes,25,opt,name=live_leaderboard,json=liveLeaderboard,proto3,oneof"`
+}
+
+type Packet_ChangePasswordRequest struct {
+	ChangePasswordRequest *ChangePasswordRequestMessage `protobuf:"bytes,26,opt,name=change_password_request,json=changePasswordRequest,proto3,oneof"`
+}
+
+type Packet_DeleteAccountRequest struct {
+	DeleteAccountRequest *DeleteAccountRequestMessage `protobuf:"bytes,27,opt,name=delete_account_request,json=deleteAccountRequest,proto3,oneof"`
+}
+
+type Packet_Kick struct {
+	Kick *KickMessage `protobuf:"bytes,28,opt,name=kick,proto3,oneof"`
+}
+
+type Packet_RoomListRequest struct {
+	RoomListRequest *RoomListRequestMessage `protobuf:"bytes,29,opt,name=room_list_request,json=roomListRequest,proto3,oneof"`
+}
+
+type Packet_RoomList struct {
+	RoomList *RoomListMessage `protobuf:"bytes,30,opt,name=room_list,json=roomList,proto3,oneof"`
+}
+
+type Packet_JoinRoomRequest struct {
+	JoinRoomRequest *JoinRoomRequestMessage `protobuf:"bytes,31,opt,name=join_room_request,json=joinRoomRequest,proto3,oneof"`
+}
+
+type Packet_RoomJoined struct {
+	RoomJoined *RoomJoinedMessage `protobuf:"bytes,32,opt,name=room_joined,json=roomJoined,proto3,oneof"`
+}
+
+type Packet_QueueRequest struct {
+	QueueRequest *QueueRequestMessage `protobuf:"bytes,33,opt,name=queue_request,json=queueRequest,proto3,oneof"`
+}
+
+type Packet_QueueStatus struct {
+	QueueStatus *QueueStatusMessage `protobuf:"bytes,34,opt,name=queue_status,json=queueStatus,proto3,oneof"`
+}
+
+type Packet_Profile struct {
+	Profile *ProfileMessage `protobuf:"bytes,35,opt,name=profile,proto3,oneof"`
+}
+
+type Packet_SetProfileRequest struct {
+	SetProfileRequest *SetProfileRequestMessage `protobuf:"bytes,36,opt,name=set_profile_request,json=setProfileRequest,proto3,oneof"`
+}
+
+type Packet_FriendListRequest struct {
+	FriendListRequest *FriendListRequestMessage `protobuf:"bytes,37,opt,name=friend_list_request,json=friendListRequest,proto3,oneof"`
+}
+
+type Packet_FriendList struct {
+	FriendList *FriendListMessage `protobuf:"bytes,38,opt,name=friend_list,json=friendList,proto3,oneof"`
+}
+
+type Packet_AddFriendRequest struct {
+	AddFriendRequest *AddFriendRequestMessage `protobuf:"bytes,39,opt,name=add_friend_request,json=addFriendRequest,proto3,oneof"`
+}
+
+type Packet_RemoveFriendRequest struct {
+	RemoveFriendRequest *RemoveFriendRequestMessage `protobuf:"bytes,40,opt,name=remove_friend_request,json=removeFriendRequest,proto3,oneof"`
+}
+
+type Packet_StatsRequest struct {
+	StatsRequest *StatsRequestMessage `protobuf:"bytes,41,opt,name=stats_request,json=statsRequest,proto3,oneof"`
+}
+
+type Packet_Stats struct {
+	Stats *StatsMessage `protobuf:"bytes,42,opt,name=stats,proto3,oneof"`
+}
+
+type Packet_LeaveGameRequest struct {
+	LeaveGameRequest *LeaveGameRequestMessage `protobuf:"bytes,43,opt,name=leave_game_request,json=leaveGameRequest,proto3,oneof"`
+}
+
+type Packet_SpectateRequest struct {
+	SpectateRequest *SpectateRequestMessage `protobuf:"bytes,44,opt,name=spectate_request,json=spectateRequest,proto3,oneof"`
+}
+
+type Packet_Spectating struct {
+	Spectating *SpectatingMessage `protobuf:"bytes,45,opt,name=spectating,proto3,oneof"`
+}
+
+type Packet_Death struct {
+	Death *DeathMessage `protobuf:"bytes,46,opt,name=death,proto3,oneof"`
+}
+
+type Packet_RespawnRequest struct {
+	RespawnRequest *RespawnRequestMessage `protobuf:"bytes,47,opt,name=respawn_request,json=respawnRequest,proto3,oneof"`
+}
+
+func (*Packet_Chat) isPacket_Msg() {}
+
+func (*Packet_Id) isPacket_Msg() {}
+
+func (*Packet_LoginRequest) isPacket_Msg() {}
 
 func (*Packet_RegisterRequest) isPacket_Msg() {}
 
@@ -879,110 +3429,545 @@ func (*Packet_SporesBatch) isPacket_Msg() {}
 
 func (*Packet_PlayerConsumed) isPacket_Msg() {}
 
+func (*Packet_OutOfView) isPacket_Msg() {}
+
+func (*Packet_Snapshot) isPacket_Msg() {}
+
+func (*Packet_SnapshotDelta) isPacket_Msg() {}
+
+func (*Packet_SnapshotAck) isPacket_Msg() {}
+
+func (*Packet_Hello) isPacket_Msg() {}
+
+func (*Packet_Session) isPacket_Msg() {}
+
+func (*Packet_ReconnectRequest) isPacket_Msg() {}
+
+func (*Packet_Shutdown) isPacket_Msg() {}
+
+func (*Packet_LeaderboardRequest) isPacket_Msg() {}
+
+func (*Packet_Leaderboard) isPacket_Msg() {}
+
+func (*Packet_LiveLeaderboard) isPacket_Msg() {}
+
+func (*Packet_ChangePasswordRequest) isPacket_Msg() {}
+
+func (*Packet_DeleteAccountRequest) isPacket_Msg() {}
+
+func (*Packet_Kick) isPacket_Msg() {}
+
+func (*Packet_RoomListRequest) isPacket_Msg() {}
+
+func (*Packet_RoomList) isPacket_Msg() {}
+
+func (*Packet_JoinRoomRequest) isPacket_Msg() {}
+
+func (*Packet_RoomJoined) isPacket_Msg() {}
+
+func (*Packet_QueueRequest) isPacket_Msg() {}
+
+func (*Packet_QueueStatus) isPacket_Msg() {}
+
+func (*Packet_Profile) isPacket_Msg() {}
+
+func (*Packet_SetProfileRequest) isPacket_Msg() {}
+
+func (*Packet_FriendListRequest) isPacket_Msg() {}
+
+func (*Packet_FriendList) isPacket_Msg() {}
+
+func (*Packet_AddFriendRequest) isPacket_Msg() {}
+
+func (*Packet_RemoveFriendRequest) isPacket_Msg() {}
+
+func (*Packet_StatsRequest) isPacket_Msg() {}
+
+func (*Packet_Stats) isPacket_Msg() {}
+
+func (*Packet_LeaveGameRequest) isPacket_Msg() {}
+
+func (*Packet_SpectateRequest) isPacket_Msg() {}
+
+func (*Packet_Spectating) isPacket_Msg() {}
+
+func (*Packet_Death) isPacket_Msg() {}
+
+func (*Packet_RespawnRequest) isPacket_Msg() {}
+
 var File_packets_proto protoreflect.FileDescriptor
 
 var file_packets_proto_rawDesc = string([]byte{
 	0x0a, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x1f, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x74,
+	0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x33, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x74,
 	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x1b, 0x0a, 0x09, 0x49, 0x64, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4d, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x1b, 0x0a,
+	0x09, 0x49, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4d, 0x0a, 0x13, 0x4c, 0x6f,
+	0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x50, 0x0a, 0x16, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x4f,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x2d, 0x0a, 0x13, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0xb1, 0x01, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x22, 0x36, 0x0a, 0x16, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x52, 0x0a, 0x0c, 0x53,
+	0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x22,
+	0x4e, 0x0a, 0x14, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x70, 0x6f, 0x72, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x70, 0x6f, 0x72, 0x65,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0x43, 0x0a, 0x12, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73, 0x70,
+	0x6f, 0x72, 0x65, 0x73, 0x22, 0x55, 0x0a, 0x15, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4e, 0x0a, 0x10, 0x4f,
+	0x75, 0x74, 0x4f, 0x66, 0x56, 0x69, 0x65, 0x77, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x08, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x49, 0x64, 0x73, 0x22, 0xb3, 0x01, 0x0a, 0x12,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x70, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65,
+	0x64, 0x22, 0x5f, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x30, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x73, 0x22, 0xd6, 0x01, 0x0a, 0x14, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x5f,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x62, 0x61, 0x73, 0x65, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2c, 0x0a, 0x05,
+	0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x65, 0x6c, 0x74,
+	0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x04, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x30, 0x0a, 0x12, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x55, 0x0a,
+	0x0c, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a,
+	0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x22, 0x26, 0x0a, 0x0e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x2f, 0x0a, 0x17,
+	0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x29, 0x0a,
+	0x0f, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x47, 0x0a, 0x19, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x22, 0xb8, 0x01, 0x0a, 0x17, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e,
+	0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0a, 0x70, 0x65, 0x61, 0x6b, 0x52, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x5f, 0x65, 0x61, 0x74, 0x65, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x61, 0x74, 0x65,
+	0x6e, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x5f, 0x63, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x22, 0x66, 0x0a, 0x12,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x65, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x22, 0x62, 0x0a, 0x1b, 0x4c, 0x69, 0x76, 0x65, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x76,
+	0x65, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c,
+	0x69, 0x76, 0x65, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x21,
+	0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x79, 0x0a, 0x1c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x77,
+	0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x55, 0x0a, 0x1b,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x22, 0x25, 0x0a, 0x0b, 0x4b, 0x69, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x18, 0x0a, 0x16, 0x52, 0x6f,
+	0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x57, 0x0a, 0x0f, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x6e, 0x66, 0x6f,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0x69, 0x0a,
+	0x0f, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x2e, 0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x6e,
+	0x66, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6f, 0x6d, 0x73,
+	0x12, 0x26, 0x0a, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x6f, 0x6f, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x16, 0x4a, 0x6f, 0x69, 0x6e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x41, 0x0a, 0x11, 0x52,
+	0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x2c, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x6e, 0x66,
+	0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x22, 0x48,
+	0x0a, 0x13, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x74, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x79, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x22, 0xca, 0x01, 0x0a, 0x12, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x71,
+	0x75, 0x65, 0x75, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x61,
+	0x69, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x77,
+	0x61, 0x69, 0x74, 0x65, 0x64, 0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x23, 0x0a, 0x0d, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x70,
+	0x72, 0x65, 0x61, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x76, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x4c, 0x0a,
+	0x18, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63,
+	0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63,
+	0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x1a, 0x0a, 0x18, 0x46,
+	0x72, 0x69, 0x65, 0x6e, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x78, 0x0a, 0x0d, 0x46, 0x72, 0x69, 0x65, 0x6e,
+	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49,
+	0x64, 0x22, 0x45, 0x0a, 0x11, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x07, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x73, 0x22, 0x35, 0x0a, 0x17, 0x41, 0x64, 0x64, 0x46,
+	0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x38, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a,
 	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x50, 0x0a, 0x16, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70,
-	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
-	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x4f, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a, 0x13,
-	0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x9b, 0x01, 0x0a, 0x0d,
-	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12,
-	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a,
-	0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72,
-	0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x36, 0x0a, 0x16, 0x50, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x22, 0x52, 0x0a, 0x0c, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12,
-	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a,
-	0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72,
-	0x61, 0x64, 0x69, 0x75, 0x73, 0x22, 0x31, 0x0a, 0x14, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f,
-	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a,
-	0x08, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x12, 0x53, 0x70, 0x6f, 0x72,
-	0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d,
-	0x0a, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
-	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x34, 0x0a,
-	0x15, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x49, 0x64, 0x22, 0x99, 0x06, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1b,
-	0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x04, 0x63,
-	0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
-	0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x64,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x02, 0x69, 0x64, 0x12, 0x43, 0x0a,
-	0x0d, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c,
-	0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0xdc, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x65, 0x61, 0x6b, 0x5f, 0x72, 0x61, 0x64,
+	0x69, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x70, 0x65, 0x61, 0x6b, 0x52,
+	0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x5f,
+	0x65, 0x61, 0x74, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x70, 0x6f,
+	0x72, 0x65, 0x73, 0x45, 0x61, 0x74, 0x65, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x61, 0x6c, 0x69, 0x76,
+	0x65, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65,
+	0x41, 0x6c, 0x69, 0x76, 0x65, 0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x61, 0x74, 0x68,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x64, 0x65, 0x61, 0x74, 0x68, 0x73, 0x22,
+	0x19, 0x0a, 0x17, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4e, 0x0a, 0x16, 0x53, 0x70,
+	0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x49, 0x0a, 0x11, 0x53, 0x70,
+	0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0xb9, 0x01, 0x0a, 0x0c, 0x44, 0x65, 0x61, 0x74, 0x68, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6b, 0x69, 0x6c, 0x6c, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x6d, 0x61,
+	0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x4d,
+	0x61, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x61, 0x6c, 0x69, 0x76,
+	0x65, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65,
+	0x41, 0x6c, 0x69, 0x76, 0x65, 0x4d, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x70, 0x61,
+	0x77, 0x6e, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d,
+	0x73, 0x22, 0x17, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x90, 0x18, 0x0a, 0x06, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x2a, 0x0a, 0x04, 0x63, 0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x24,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x43, 0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f, 0x67,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x6f, 0x6b, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x6f, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64,
+	0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x4c, 0x0a,
+	0x10, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x05, 0x73,
+	0x70, 0x6f, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x70,
+	0x6f, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f,
+	0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x65, 0x64, 0x12, 0x40, 0x0a, 0x0c, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x49, 0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x63,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12,
+	0x3b, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x5f, 0x6f, 0x66, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4f,
+	0x75, 0x74, 0x4f, 0x66, 0x56, 0x69, 0x65, 0x77, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x09, 0x6f, 0x75, 0x74, 0x4f, 0x66, 0x56, 0x69, 0x65, 0x77, 0x12, 0x36, 0x0a, 0x08,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0c,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x2d,
+	0x0a, 0x05, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x33, 0x0a,
+	0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x10, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x08, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x55, 0x0a, 0x13, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x12,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x12, 0x4c, 0x0a, 0x10, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x6c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x76, 0x65, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0f, 0x6c, 0x69, 0x76, 0x65, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x5f, 0x0a, 0x17, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x1a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x15, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x5c, 0x0a, 0x16, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x1b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2a, 0x0a, 0x04, 0x6b, 0x69, 0x63, 0x6b, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69, 0x63, 0x6b, 0x12, 0x4d, 0x0a, 0x11,
+	0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x6f, 0x6f, 0x6d,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x72,
+	0x6f, 0x6f, 0x6d, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x08, 0x72, 0x6f, 0x6f, 0x6d,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x11, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x72, 0x6f, 0x6f,
+	0x6d, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x0f, 0x6a, 0x6f, 0x69, 0x6e, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x6a, 0x6f, 0x69, 0x6e,
+	0x65, 0x64, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x69, 0x6e,
+	0x65, 0x64, 0x12, 0x43, 0x0a, 0x0d, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x53,
+	0x0a, 0x13, 0x73, 0x65, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x11, 0x73, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x53, 0x0a, 0x13, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x72, 0x69, 0x65, 0x6e,
+	0x64, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x11, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x66, 0x72, 0x69, 0x65,
+	0x6e, 0x64, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x26, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x66, 0x72, 0x69,
+	0x65, 0x6e, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x50, 0x0a, 0x12, 0x61, 0x64, 0x64, 0x5f, 0x66,
+	0x72, 0x69, 0x65, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x27, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x64,
+	0x64, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x10, 0x61, 0x64, 0x64, 0x46, 0x72, 0x69, 0x65,
+	0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x59, 0x0a, 0x15, 0x72, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x5f, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
-	0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x3d, 0x0a, 0x0b, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x48, 0x00, 0x52, 0x0a, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x43, 0x0a, 0x0d, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50,
-	0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06,
-	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x4c, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x18, 0x0a, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70,
-	0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x70,
-	0x6f, 0x72, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x6e,
-	0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61,
-	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75,
-	0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x70,
-	0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0c, 0x73,
-	0x70, 0x6f, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72,
-	0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
-	0x52, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x49, 0x0a,
-	0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64,
-	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x42,
-	0x0d, 0x5a, 0x0b, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x13, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x50, 0x0a, 0x12, 0x6c, 0x65, 0x61, 0x76,
+	0x65, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x2b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c,
+	0x65, 0x61, 0x76, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x10, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x47,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x2c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53,
+	0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2d, 0x0a, 0x05, 0x64, 0x65, 0x61, 0x74, 0x68, 0x18,
+	0x2e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x44, 0x65, 0x61, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05,
+	0x64, 0x65, 0x61, 0x74, 0x68, 0x12, 0x49, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x4a, 0x04, 0x08, 0x0e, 0x10, 0x0f, 0x42, 0x0d, 0x5a,
+	0x0b, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -997,41 +3982,120 @@ func file_packets_proto_rawDescGZIP() []byte {
 	return file_packets_proto_rawDescData
 }
 
-var file_packets_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_packets_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_packets_proto_goTypes = []any{
-	(*ChatMessage)(nil),            // 0: packets.ChatMessage
-	(*IdMessage)(nil),              // 1: packets.IdMessage
-	(*LoginRequestMessage)(nil),    // 2: packets.LoginRequestMessage
-	(*RegisterRequestMessage)(nil), // 3: packets.RegisterRequestMessage
-	(*OkResponseMessage)(nil),      // 4: packets.OkResponseMessage
-	(*DenyResponseMessage)(nil),    // 5: packets.DenyResponseMessage
-	(*PlayerMessage)(nil),          // 6: packets.PlayerMessage
-	(*PlayerDirectionMessage)(nil), // 7: packets.PlayerDirectionMessage
-	(*SporeMessage)(nil),           // 8: packets.SporeMessage
-	(*SporeConsumedMessage)(nil),   // 9: packets.SporeConsumedMessage
-	(*SporesBatchMessage)(nil),     // 10: packets.SporesBatchMessage
-	(*PlayerConsumedMessage)(nil),  // 11: packets.PlayerConsumedMessage
-	(*Packet)(nil),                 // 12: packets.Packet
+	(*ChatMessage)(nil),                  // 0: packets.ChatMessage
+	(*IdMessage)(nil),                    // 1: packets.IdMessage
+	(*LoginRequestMessage)(nil),          // 2: packets.LoginRequestMessage
+	(*RegisterRequestMessage)(nil),       // 3: packets.RegisterRequestMessage
+	(*OkResponseMessage)(nil),            // 4: packets.OkResponseMessage
+	(*DenyResponseMessage)(nil),          // 5: packets.DenyResponseMessage
+	(*PlayerMessage)(nil),                // 6: packets.PlayerMessage
+	(*PlayerDirectionMessage)(nil),       // 7: packets.PlayerDirectionMessage
+	(*SporeMessage)(nil),                 // 8: packets.SporeMessage
+	(*SporeConsumedMessage)(nil),         // 9: packets.SporeConsumedMessage
+	(*SporesBatchMessage)(nil),           // 10: packets.SporesBatchMessage
+	(*PlayerConsumedMessage)(nil),        // 11: packets.PlayerConsumedMessage
+	(*OutOfViewMessage)(nil),             // 12: packets.OutOfViewMessage
+	(*PlayerDeltaMessage)(nil),           // 13: packets.PlayerDeltaMessage
+	(*SnapshotMessage)(nil),              // 14: packets.SnapshotMessage
+	(*SnapshotDeltaMessage)(nil),         // 15: packets.SnapshotDeltaMessage
+	(*SnapshotAckMessage)(nil),           // 16: packets.SnapshotAckMessage
+	(*HelloMessage)(nil),                 // 17: packets.HelloMessage
+	(*SessionMessage)(nil),               // 18: packets.SessionMessage
+	(*ReconnectRequestMessage)(nil),      // 19: packets.ReconnectRequestMessage
+	(*ShutdownMessage)(nil),              // 20: packets.ShutdownMessage
+	(*LeaderboardRequestMessage)(nil),    // 21: packets.LeaderboardRequestMessage
+	(*LeaderboardEntryMessage)(nil),      // 22: packets.LeaderboardEntryMessage
+	(*LeaderboardMessage)(nil),           // 23: packets.LeaderboardMessage
+	(*LiveLeaderboardEntryMessage)(nil),  // 24: packets.LiveLeaderboardEntryMessage
+	(*LiveLeaderboardMessage)(nil),       // 25: packets.LiveLeaderboardMessage
+	(*ChangePasswordRequestMessage)(nil), // 26: packets.ChangePasswordRequestMessage
+	(*DeleteAccountRequestMessage)(nil),  // 27: packets.DeleteAccountRequestMessage
+	(*KickMessage)(nil),                  // 28: packets.KickMessage
+	(*RoomListRequestMessage)(nil),       // 29: packets.RoomListRequestMessage
+	(*RoomInfoMessage)(nil),              // 30: packets.RoomInfoMessage
+	(*RoomListMessage)(nil),              // 31: packets.RoomListMessage
+	(*JoinRoomRequestMessage)(nil),       // 32: packets.JoinRoomRequestMessage
+	(*RoomJoinedMessage)(nil),            // 33: packets.RoomJoinedMessage
+	(*QueueRequestMessage)(nil),          // 34: packets.QueueRequestMessage
+	(*QueueStatusMessage)(nil),           // 35: packets.QueueStatusMessage
+	(*ProfileMessage)(nil),               // 36: packets.ProfileMessage
+	(*SetProfileRequestMessage)(nil),     // 37: packets.SetProfileRequestMessage
+	(*FriendListRequestMessage)(nil),     // 38: packets.FriendListRequestMessage
+	(*FriendMessage)(nil),                // 39: packets.FriendMessage
+	(*FriendListMessage)(nil),            // 40: packets.FriendListMessage
+	(*AddFriendRequestMessage)(nil),      // 41: packets.AddFriendRequestMessage
+	(*RemoveFriendRequestMessage)(nil),   // 42: packets.RemoveFriendRequestMessage
+	(*StatsRequestMessage)(nil),          // 43: packets.StatsRequestMessage
+	(*StatsMessage)(nil),                 // 44: packets.StatsMessage
+	(*LeaveGameRequestMessage)(nil),      // 45: packets.LeaveGameRequestMessage
+	(*SpectateRequestMessage)(nil),       // 46: packets.SpectateRequestMessage
+	(*SpectatingMessage)(nil),            // 47: packets.SpectatingMessage
+	(*DeathMessage)(nil),                 // 48: packets.DeathMessage
+	(*RespawnRequestMessage)(nil),        // 49: packets.RespawnRequestMessage
+	(*Packet)(nil),                       // 50: packets.Packet
 }
 var file_packets_proto_depIdxs = []int32{
 	8,  // 0: packets.SporesBatchMessage.spores:type_name -> packets.SporeMessage
-	0,  // 1: packets.Packet.chat:type_name -> packets.ChatMessage
-	1,  // 2: packets.Packet.id:type_name -> packets.IdMessage
-	2,  // 3: packets.Packet.login_request:type_name -> packets.LoginRequestMessage
-	3,  // 4: packets.Packet.register_request:type_name -> packets.RegisterRequestMessage
-	4,  // 5: packets.Packet.ok_response:type_name -> packets.OkResponseMessage
-	5,  // 6: packets.Packet.deny_response:type_name -> packets.DenyResponseMessage
-	6,  // 7: packets.Packet.player:type_name -> packets.PlayerMessage
-	7,  // 8: packets.Packet.player_direction:type_name -> packets.PlayerDirectionMessage
-	8,  // 9: packets.Packet.spore:type_name -> packets.SporeMessage
-	9,  // 10: packets.Packet.spore_consumed:type_name -> packets.SporeConsumedMessage
-	10, // 11: packets.Packet.spores_batch:type_name -> packets.SporesBatchMessage
-	11, // 12: packets.Packet.player_consumed:type_name -> packets.PlayerConsumedMessage
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	6,  // 1: packets.SnapshotMessage.players:type_name -> packets.PlayerMessage
+	6,  // 2: packets.SnapshotDeltaMessage.added:type_name -> packets.PlayerMessage
+	13, // 3: packets.SnapshotDeltaMessage.changed:type_name -> packets.PlayerDeltaMessage
+	22, // 4: packets.LeaderboardMessage.entries:type_name -> packets.LeaderboardEntryMessage
+	24, // 5: packets.LiveLeaderboardMessage.entries:type_name -> packets.LiveLeaderboardEntryMessage
+	30, // 6: packets.RoomListMessage.rooms:type_name -> packets.RoomInfoMessage
+	30, // 7: packets.RoomJoinedMessage.room:type_name -> packets.RoomInfoMessage
+	39, // 8: packets.FriendListMessage.friends:type_name -> packets.FriendMessage
+	0,  // 9: packets.Packet.chat:type_name -> packets.ChatMessage
+	1,  // 10: packets.Packet.id:type_name -> packets.IdMessage
+	2,  // 11: packets.Packet.login_request:type_name -> packets.LoginRequestMessage
+	3,  // 12: packets.Packet.register_request:type_name -> packets.RegisterRequestMessage
+	4,  // 13: packets.Packet.ok_response:type_name -> packets.OkResponseMessage
+	5,  // 14: packets.Packet.deny_response:type_name -> packets.DenyResponseMessage
+	6,  // 15: packets.Packet.player:type_name -> packets.PlayerMessage
+	7,  // 16: packets.Packet.player_direction:type_name -> packets.PlayerDirectionMessage
+	8,  // 17: packets.Packet.spore:type_name -> packets.SporeMessage
+	9,  // 18: packets.Packet.spore_consumed:type_name -> packets.SporeConsumedMessage
+	10, // 19: packets.Packet.spores_batch:type_name -> packets.SporesBatchMessage
+	11, // 20: packets.Packet.player_consumed:type_name -> packets.PlayerConsumedMessage
+	12, // 21: packets.Packet.out_of_view:type_name -> packets.OutOfViewMessage
+	14, // 22: packets.Packet.snapshot:type_name -> packets.SnapshotMessage
+	15, // 23: packets.Packet.snapshot_delta:type_name -> packets.SnapshotDeltaMessage
+	16, // 24: packets.Packet.snapshot_ack:type_name -> packets.SnapshotAckMessage
+	17, // 25: packets.Packet.hello:type_name -> packets.HelloMessage
+	18, // 26: packets.Packet.session:type_name -> packets.SessionMessage
+	19, // 27: packets.Packet.reconnect_request:type_name -> packets.ReconnectRequestMessage
+	20, // 28: packets.Packet.shutdown:type_name -> packets.ShutdownMessage
+	21, // 29: packets.Packet.leaderboard_request:type_name -> packets.LeaderboardRequestMessage
+	23, // 30: packets.Packet.leaderboard:type_name -> packets.LeaderboardMessage
+	25, // 31: packets.Packet.live_leaderboard:type_name -> packets.LiveLeaderboardMessage
+	26, // 32: packets.Packet.change_password_request:type_name -> packets.ChangePasswordRequestMessage
+	27, // 33: packets.Packet.delete_account_request:type_name -> packets.DeleteAccountRequestMessage
+	28, // 34: packets.Packet.kick:type_name -> packets.KickMessage
+	29, // 35: packets.Packet.room_list_request:type_name -> packets.RoomListRequestMessage
+	31, // 36: packets.Packet.room_list:type_name -> packets.RoomListMessage
+	32, // 37: packets.Packet.join_room_request:type_name -> packets.JoinRoomRequestMessage
+	33, // 38: packets.Packet.room_joined:type_name -> packets.RoomJoinedMessage
+	34, // 39: packets.Packet.queue_request:type_name -> packets.QueueRequestMessage
+	35, // 40: packets.Packet.queue_status:type_name -> packets.QueueStatusMessage
+	36, // 41: packets.Packet.profile:type_name -> packets.ProfileMessage
+	37, // 42: packets.Packet.set_profile_request:type_name -> packets.SetProfileRequestMessage
+	38, // 43: packets.Packet.friend_list_request:type_name -> packets.FriendListRequestMessage
+	40, // 44: packets.Packet.friend_list:type_name -> packets.FriendListMessage
+	41, // 45: packets.Packet.add_friend_request:type_name -> packets.AddFriendRequestMessage
+	42, // 46: packets.Packet.remove_friend_request:type_name -> packets.RemoveFriendRequestMessage
+	43, // 47: packets.Packet.stats_request:type_name -> packets.StatsRequestMessage
+	44, // 48: packets.Packet.stats:type_name -> packets.StatsMessage
+	45, // 49: packets.Packet.leave_game_request:type_name -> packets.LeaveGameRequestMessage
+	46, // 50: packets.Packet.spectate_request:type_name -> packets.SpectateRequestMessage
+	47, // 51: packets.Packet.spectating:type_name -> packets.SpectatingMessage
+	48, // 52: packets.Packet.death:type_name -> packets.DeathMessage
+	49, // 53: packets.Packet.respawn_request:type_name -> packets.RespawnRequestMessage
+	54, // [54:54] is the sub-list for method output_type
+	54, // [54:54] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
 }
 
 func init() { file_packets_proto_init() }
@@ -1039,7 +4103,7 @@ func file_packets_proto_init() {
 	if File_packets_proto != nil {
 		return
 	}
-	file_packets_proto_msgTypes[12].OneofWrappers = []any{
+	file_packets_proto_msgTypes[50].OneofWrappers = []any{
 		(*Packet_Chat)(nil),
 		(*Packet_Id)(nil),
 		(*Packet_LoginRequest)(nil),
@@ -1052,6 +4116,39 @@ func file_packets_proto_init() {
 		(*Packet_SporeConsumed)(nil),
 		(*Packet_SporesBatch)(nil),
 		(*Packet_PlayerConsumed)(nil),
+		(*Packet_OutOfView)(nil),
+		(*Packet_Snapshot)(nil),
+		(*Packet_SnapshotDelta)(nil),
+		(*Packet_SnapshotAck)(nil),
+		(*Packet_Hello)(nil),
+		(*Packet_Session)(nil),
+		(*Packet_ReconnectRequest)(nil),
+		(*Packet_Shutdown)(nil),
+		(*Packet_LeaderboardRequest)(nil),
+		(*Packet_Leaderboard)(nil),
+		(*Packet_LiveLeaderboard)(nil),
+		(*Packet_ChangePasswordRequest)(nil),
+		(*Packet_DeleteAccountRequest)(nil),
+		(*Packet_Kick)(nil),
+		(*Packet_RoomListRequest)(nil),
+		(*Packet_RoomList)(nil),
+		(*Packet_JoinRoomRequest)(nil),
+		(*Packet_RoomJoined)(nil),
+		(*Packet_QueueRequest)(nil),
+		(*Packet_QueueStatus)(nil),
+		(*Packet_Profile)(nil),
+		(*Packet_SetProfileRequest)(nil),
+		(*Packet_FriendListRequest)(nil),
+		(*Packet_FriendList)(nil),
+		(*Packet_AddFriendRequest)(nil),
+		(*Packet_RemoveFriendRequest)(nil),
+		(*Packet_StatsRequest)(nil),
+		(*Packet_Stats)(nil),
+		(*Packet_LeaveGameRequest)(nil),
+		(*Packet_SpectateRequest)(nil),
+		(*Packet_Spectating)(nil),
+		(*Packet_Death)(nil),
+		(*Packet_RespawnRequest)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1059,7 +4156,7 @@ func file_packets_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_packets_proto_rawDesc), len(file_packets_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,76 @@
+package packets
+
+import "slices"
+
+// 当前的协议版本，packets.proto 有不兼容的修改时加一。
+// 1 是 Client/ 里的 Godot 客户端用的协议，没有握手；2 加上了握手和快照；
+// 3 去掉了 players_batch，登录之后先进大厅，被吞并之后不再马上重生
+const ProtocolVersion uint32 = 3
+
+// 握手的客户端最低的协议版本
+const MinProtocolVersion uint32 = 3
+
+// 不握手的旧客户端（Client/ 里的 Godot 客户端）。握手之前就注册或者登录的连接按这个版本处理：
+// 没有可选功能，登录之后直接进入游戏，Hub 每次 tick 给它发视野里每个玩家的 player 消息，不发快照
+const LegacyProtocolVersion uint32 = 1
+
+// 握手时可以协商的可选功能
+const (
+	FeatureSnapshotDelta = "snapshot_delta"
+	FeatureSporesBatch   = "spores_batch"
+	FeatureOutOfView     = "out_of_view"
+)
+
+// 服务器支持的所有可选功能
+var SupportedFeatures = []string{
+	FeatureSnapshotDelta,
+	FeatureSporesBatch,
+	FeatureOutOfView,
+}
+
+func NewHello(protocolVersion uint32, features []string) Msg {
+	return &Packet_Hello{
+		Hello: &HelloMessage{
+			ProtocolVersion: protocolVersion,
+			Features:        features,
+		},
+	}
+}
+
+// 客户端请求的功能里服务器也支持的部分
+func NegotiateFeatures(requested []string) []string {
+	var accepted []string
+	for _, feature := range requested {
+		if slices.Contains(SupportedFeatures, feature) && !slices.Contains(accepted, feature) {
+			accepted = append(accepted, feature)
+		}
+	}
+	return accepted
+}
+
+// 换成协议 1 里的消息。旧客户端的 godobuf 遇到不认识的字段就解析失败，
+// 所以去掉后来加上的字段；协议 1 里没有的消息返回 nil，不发给旧客户端
+func LegacyMessage(message Msg) Msg {
+	switch message := message.(type) {
+	case *Packet_Id, *Packet_OkResponse, *Packet_DenyResponse, *Packet_Spore, *Packet_SporesBatch:
+		return message
+	case *Packet_Chat:
+		return &Packet_Chat{Chat: &ChatMessage{Msg: message.Chat.Msg}}
+	case *Packet_Player:
+		player := message.Player
+		return &Packet_Player{Player: &PlayerMessage{
+			Id:        player.Id,
+			Name:      player.Name,
+			X:         player.X,
+			Y:         player.Y,
+			Radius:    player.Radius,
+			Direction: player.Direction,
+			Speed:     player.Speed,
+		}}
+	case *Packet_SporeConsumed:
+		return &Packet_SporeConsumed{SporeConsumed: &SporeConsumedMessage{SporeId: message.SporeConsumed.SporeId}}
+	case *Packet_PlayerConsumed:
+		return &Packet_PlayerConsumed{PlayerConsumed: &PlayerConsumedMessage{PlayerId: message.PlayerConsumed.PlayerId}}
+	}
+	return nil
+}
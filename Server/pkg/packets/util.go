@@ -1,6 +1,24 @@
 package packets
 
-import "server/internal/server/objects"
+import (
+	"server/internal/server/objects"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// 批量包外层（Packet 和发送者 ID）预留的字节数
+const batchOverhead int = 32
+
+// PlayerDeltaMessage 里 changed_fields 的每一位对应一个字段
+const (
+	PlayerFieldX uint32 = 1 << iota
+	PlayerFieldY
+	PlayerFieldRadius
+	PlayerFieldDirection
+	PlayerFieldSpeed
+)
 
 type Msg = isPacket_Msg
 
@@ -12,6 +30,16 @@ func NewChat(text string) Msg {
 	}
 }
 
+// 大厅聊天，带上发送者的昵称
+func NewNamedChat(name string, text string) Msg {
+	return &Packet_Chat{
+		Chat: &ChatMessage{
+			Msg:  text,
+			Name: name,
+		},
+	}
+}
+
 // NewId creates a new packet with the type "id" and the given id number.
 //
 // The returned packet is a pointer to a Packet_Id struct.
@@ -31,6 +59,33 @@ func NewDenyResponse(reason string) Msg {
 	}
 }
 
+// 服务器关闭前通知客户端
+func NewShutdown(reason string) Msg {
+	return &Packet_Shutdown{
+		Shutdown: &ShutdownMessage{
+			Reason: reason,
+		},
+	}
+}
+
+// 被服务器踢下线之前通知客户端，比如同一个账号在别处登录了
+func NewKick(reason string) Msg {
+	return &Packet_Kick{
+		Kick: &KickMessage{
+			Reason: reason,
+		},
+	}
+}
+
+// 登录成功后发给客户端的会话令牌，断线重连时使用
+func NewSession(token string) Msg {
+	return &Packet_Session{
+		Session: &SessionMessage{
+			Token: token,
+		},
+	}
+}
+
 func NewOkResponse() Msg {
 	return &Packet_OkResponse{
 		OkResponse: &OkResponseMessage{},
@@ -40,26 +95,276 @@ func NewOkResponse() Msg {
 //构建新的玩家的信息
 func NewPlayer(id uint64, player *objects.Player) Msg {
 	return &Packet_Player{
-		Player: &PlayerMessage{
-			Id:        id,
-			Name:      player.Name,
-			X:         player.X,
-			Y:         player.Y,
-			Radius:    player.Radius,
-			Direction: player.Direction,
-			Speed:     player.Speed,
-		},
+		Player: NewPlayerMessage(id, player),
+	}
+}
+
+// 不带包装的玩家信息，用来组成批量的包
+func NewPlayerMessage(id uint64, player *objects.Player) *PlayerMessage {
+	return &PlayerMessage{
+		Id:        id,
+		Name:      player.Name,
+		X:         player.X,
+		Y:         player.Y,
+		Radius:    player.Radius,
+		Direction: player.Direction,
+		Speed:     player.Speed,
+		Color:     player.Color,
 	}
 }
 
 //生成一个孢子
 func NewSpore(id uint64, spore *objects.Spore) Msg {
 	return &Packet_Spore{
-		Spore: &SporeMessage{
-			Id:     id,
-			X:      spore.X,
-			Y:      spore.Y,
-			Radius: spore.Radius,
+		Spore: NewSporeMessage(id, spore),
+	}
+}
+
+// 不带包装的孢子信息，用来组成批量的包
+func NewSporeMessage(id uint64, spore *objects.Spore) *SporeMessage {
+	return &SporeMessage{
+		Id:     id,
+		X:      spore.X,
+		Y:      spore.Y,
+		Radius: spore.Radius,
+	}
+}
+
+// 多个孢子合并成一个包
+func NewSporesBatch(spores []*SporeMessage) Msg {
+	return &Packet_SporesBatch{
+		SporesBatch: &SporesBatchMessage{
+			Spores: spores,
+		},
+	}
+}
+
+// 把孢子分成多个批量包，每个包编码后不超过 maxFrameSize 字节
+func NewSporesBatches(spores []*SporeMessage, maxFrameSize int) []Msg {
+	var batches []Msg
+	var chunk []*SporeMessage
+	chunkSize := batchOverhead
+
+	for _, spore := range spores {
+		sporeSize := protowire.SizeTag(1) + protowire.SizeBytes(proto.Size(spore))
+
+		if len(chunk) > 0 && chunkSize+sporeSize > maxFrameSize {
+			batches = append(batches, NewSporesBatch(chunk))
+			chunk = nil
+			chunkSize = batchOverhead
+		}
+
+		chunk = append(chunk, spore)
+		chunkSize += sporeSize
+	}
+
+	if len(chunk) > 0 {
+		batches = append(batches, NewSporesBatch(chunk))
+	}
+
+	return batches
+}
+
+// 服务器判定的吃孢子事件
+func NewSporeConsumed(sporeId uint64, playerId uint64) Msg {
+	return &Packet_SporeConsumed{
+		SporeConsumed: &SporeConsumedMessage{
+			SporeId:  sporeId,
+			PlayerId: playerId,
+		},
+	}
+}
+
+// 服务器判定的吞并玩家事件
+func NewPlayerConsumed(playerId uint64, consumerId uint64) Msg {
+	return &Packet_PlayerConsumed{
+		PlayerConsumed: &PlayerConsumedMessage{
+			PlayerId:   playerId,
+			ConsumerId: consumerId,
+		},
+	}
+}
+
+// 离开视野的玩家和孢子
+func NewOutOfView(playerIds []uint64, sporeIds []uint64) Msg {
+	return &Packet_OutOfView{
+		OutOfView: &OutOfViewMessage{
+			PlayerIds: playerIds,
+			SporeIds:  sporeIds,
+		},
+	}
+}
+
+// 完整的快照，客户端没有确认过的基准时发送
+func NewSnapshot(sequence uint64, players []*PlayerMessage) Msg {
+	return &Packet_Snapshot{
+		Snapshot: &SnapshotMessage{
+			Sequence: sequence,
+			Players:  players,
+		},
+	}
+}
+
+// 相对于客户端确认过的快照的差量
+func NewSnapshotDelta(sequence uint64, baseSequence uint64, added []*PlayerMessage, changed []*PlayerDeltaMessage, removed []uint64) Msg {
+	return &Packet_SnapshotDelta{
+		SnapshotDelta: &SnapshotDeltaMessage{
+			Sequence:     sequence,
+			BaseSequence: baseSequence,
+			Added:        added,
+			Changed:      changed,
+			Removed:      removed,
+		},
+	}
+}
+
+func NewSnapshotAck(sequence uint64) Msg {
+	return &Packet_SnapshotAck{
+		SnapshotAck: &SnapshotAckMessage{
+			Sequence: sequence,
+		},
+	}
+}
+
+// 比较同一个玩家的两个状态，只带上变化了的字段，没有变化时返回 nil
+func NewPlayerDelta(old *PlayerMessage, current *PlayerMessage) *PlayerDeltaMessage {
+	delta := &PlayerDeltaMessage{Id: current.Id}
+
+	if current.X != old.X {
+		delta.ChangedFields |= PlayerFieldX
+		delta.X = current.X
+	}
+	if current.Y != old.Y {
+		delta.ChangedFields |= PlayerFieldY
+		delta.Y = current.Y
+	}
+	if current.Radius != old.Radius {
+		delta.ChangedFields |= PlayerFieldRadius
+		delta.Radius = current.Radius
+	}
+	if current.Direction != old.Direction {
+		delta.ChangedFields |= PlayerFieldDirection
+		delta.Direction = current.Direction
+	}
+	if current.Speed != old.Speed {
+		delta.ChangedFields |= PlayerFieldSpeed
+		delta.Speed = current.Speed
+	}
+
+	if delta.ChangedFields == 0 {
+		return nil
+	}
+	return delta
+}
+
+// 排行榜，daily 表示当天（UTC）的排行
+func NewLeaderboard(daily bool, entries []*LeaderboardEntryMessage) Msg {
+	return &Packet_Leaderboard{
+		Leaderboard: &LeaderboardMessage{
+			Daily:   daily,
+			Entries: entries,
+		},
+	}
+}
+
+// 比赛中的实时排行榜，按质量从大到小，ownRank 是收到的玩家自己的名次
+func NewLiveLeaderboard(entries []*LiveLeaderboardEntryMessage, ownRank uint32, playerCount uint32) Msg {
+	return &Packet_LiveLeaderboard{
+		LiveLeaderboard: &LiveLeaderboardMessage{
+			Entries:     entries,
+			OwnRank:     ownRank,
+			PlayerCount: playerCount,
+		},
+	}
+}
+
+// 房间列表，current 是客户端所在的房间，0 表示还没有进入房间
+func NewRoomList(rooms []*RoomInfoMessage, current uint64) Msg {
+	return &Packet_RoomList{
+		RoomList: &RoomListMessage{
+			Rooms:         rooms,
+			CurrentRoomId: current,
+		},
+	}
+}
+
+// 进入房间之后通知客户端，之前的房间里的对象都不再有效
+func NewRoomJoined(room *RoomInfoMessage) Msg {
+	return &Packet_RoomJoined{
+		RoomJoined: &RoomJoinedMessage{
+			Room: room,
+		},
+	}
+}
+
+// 匹配队列的状态：名次、排队人数、等了多久，以及现在允许的分数差
+func NewQueueStatus(position uint32, queueSize uint32, waited time.Duration, rating int64, ratingSpread uint32, targetSize uint32) Msg {
+	return &Packet_QueueStatus{
+		QueueStatus: &QueueStatusMessage{
+			Position:     position,
+			QueueSize:    queueSize,
+			WaitedMs:     uint64(waited.Milliseconds()),
+			Rating:       rating,
+			RatingSpread: ratingSpread,
+			TargetSize:   targetSize,
+		},
+	}
+}
+
+// 大厅里显示的账号信息
+func NewProfile(username string, nickname string, color uint32, rating int64) Msg {
+	return &Packet_Profile{
+		Profile: &ProfileMessage{
+			Username: username,
+			Nickname: nickname,
+			Color:    color,
+			Rating:   rating,
+		},
+	}
+}
+
+// 好友列表，按用户名排序
+func NewFriendList(friends []*FriendMessage) Msg {
+	return &Packet_FriendList{
+		FriendList: &FriendListMessage{
+			Friends: friends,
+		},
+	}
+}
+
+// 自己的累计统计
+func NewStats(gamesPlayed uint64, peakRadius float64, sporesEaten uint64, playersConsumed uint64, timeAlive time.Duration, deaths uint64) Msg {
+	return &Packet_Stats{
+		Stats: &StatsMessage{
+			GamesPlayed:     gamesPlayed,
+			PeakRadius:      peakRadius,
+			SporesEaten:     sporesEaten,
+			PlayersConsumed: playersConsumed,
+			TimeAliveMs:     uint64(timeAlive.Milliseconds()),
+			Deaths:          deaths,
+		},
+	}
+}
+
+// 开始观战或者换了跟随的玩家，playerId 为 0 表示跟着房间里最大的玩家
+func NewSpectating(roomId uint64, playerId uint64) Msg {
+	return &Packet_Spectating{
+		Spectating: &SpectatingMessage{
+			RoomId:   roomId,
+			PlayerId: playerId,
+		},
+	}
+}
+
+// 自己的玩家被吞并了，respawnDelay 之后自动重生，观战时为 0
+func NewDeath(killerId uint64, killerName string, finalMass float64, timeAlive time.Duration, respawnDelay time.Duration) Msg {
+	return &Packet_Death{
+		Death: &DeathMessage{
+			KillerId:       killerId,
+			KillerName:     killerName,
+			FinalMass:      finalMass,
+			TimeAliveMs:    uint64(timeAlive.Milliseconds()),
+			RespawnDelayMs: uint64(respawnDelay.Milliseconds()),
 		},
 	}
 }
@@ -0,0 +1,67 @@
+package packets
+
+import "testing"
+
+func TestNewPlayerDelta(t *testing.T) {
+	old := &PlayerMessage{Id: 7, Name: "alice", X: 1, Y: 2, Radius: 20, Direction: 0.5, Speed: 150, Color: 0xFF0000}
+
+	tests := []struct {
+		name    string
+		change  func(p *PlayerMessage)
+		want    uint32
+		wantNil bool
+	}{
+		{name: "unchanged", change: func(p *PlayerMessage) {}, wantNil: true},
+		// 名字和颜色不在差量里，新进入视野时已经完整发过了
+		{name: "name and color", change: func(p *PlayerMessage) { p.Name = "bob"; p.Color = 0 }, wantNil: true},
+		{name: "x", change: func(p *PlayerMessage) { p.X = 3 }, want: PlayerFieldX},
+		{name: "y", change: func(p *PlayerMessage) { p.Y = 0 }, want: PlayerFieldY},
+		{name: "radius", change: func(p *PlayerMessage) { p.Radius = 25 }, want: PlayerFieldRadius},
+		{name: "direction", change: func(p *PlayerMessage) { p.Direction = -1 }, want: PlayerFieldDirection},
+		{name: "speed", change: func(p *PlayerMessage) { p.Speed = 0 }, want: PlayerFieldSpeed},
+		{name: "position", change: func(p *PlayerMessage) { p.X = 10; p.Y = 20 }, want: PlayerFieldX | PlayerFieldY},
+		{
+			name:   "everything",
+			change: func(p *PlayerMessage) { p.X, p.Y, p.Radius, p.Direction, p.Speed = 9, 9, 9, 9, 9 },
+			want:   PlayerFieldX | PlayerFieldY | PlayerFieldRadius | PlayerFieldDirection | PlayerFieldSpeed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &PlayerMessage{Id: old.Id, Name: old.Name, X: old.X, Y: old.Y, Radius: old.Radius, Direction: old.Direction, Speed: old.Speed, Color: old.Color}
+			tt.change(current)
+
+			delta := NewPlayerDelta(old, current)
+			if tt.wantNil {
+				if delta != nil {
+					t.Fatalf("got %v, want no delta", delta)
+				}
+				return
+			}
+			if delta == nil {
+				t.Fatal("got no delta")
+			}
+
+			if delta.Id != old.Id || delta.ChangedFields != tt.want {
+				t.Errorf("got id %d, changed fields %05b, want %d, %05b", delta.Id, delta.ChangedFields, old.Id, tt.want)
+			}
+			// 变化了的字段带上新的值
+			checks := []struct {
+				field     uint32
+				got, want float64
+			}{
+				{PlayerFieldX, delta.X, current.X},
+				{PlayerFieldY, delta.Y, current.Y},
+				{PlayerFieldRadius, delta.Radius, current.Radius},
+				{PlayerFieldDirection, delta.Direction, current.Direction},
+				{PlayerFieldSpeed, delta.Speed, current.Speed},
+			}
+			for _, check := range checks {
+				if delta.ChangedFields&check.field != 0 && check.got != check.want {
+					t.Errorf("field %05b = %f, want %f", check.field, check.got, check.want)
+				}
+			}
+		})
+	}
+}